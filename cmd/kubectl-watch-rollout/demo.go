@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/simulator"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// demoCluster is the cluster simulated rollouts are recorded for in the rollout history.
+const demoCluster = "demo"
+
+// demoOptions holds values of the demo command's flags.
+type demoOptions struct {
+	scenario          string
+	replicas          int32
+	maxSurge          string
+	maxUnavailable    string
+	step              time.Duration
+	startAfter        int
+	progressDeadline  time.Duration
+	untilComplete     bool
+	rollbackOnFailure bool
+	lineMode          bool
+	output            string
+	metricsAddr       string
+	historyFile       string
+	etaModel          string
+	notify            notifyOptions
+}
+
+// newDemoCommand creates the command watching a simulated rollout.
+func newDemoCommand() *cobra.Command {
+	var opts demoOptions
+
+	defaults := simulator.DefaultOptions()
+
+	cmd := &cobra.Command{
+		Use:   "demo",
+		Short: "Watch a simulated rollout, no cluster needed",
+		Long: `Watch a simulated deployment rollout through the TUI, line or JSON Lines view.
+
+A built-in engine plays the deployment controller and kubelet, rolling pods over step by step
+within --max-surge and --max-unavailable. --scenario injects a failure into the new revision:
+
+  success    new pods start healthy and the rollout completes
+  deadline   new pods fail their readiness probe until the progress deadline is exceeded
+  crashloop  new containers exit on start and end up in CrashLoopBackOff
+  imagepull  the new image tag does not exist, new pods end up in ImagePullBackOff
+  quota      the namespace resource quota rejects new pods (ReplicaFailure)
+
+No kubeconfig or cluster access is used.`,
+		Example: `  # Successful rollout of 10 replicas, one pod at a time
+  kubectl watch-rollout demo --replicas=10 --max-surge=1 --max-unavailable=0
+
+  # What a crash looping release looks like, and how --rollback-on-failure reverts it
+  kubectl watch-rollout demo --scenario=crashloop --until-complete --rollback-on-failure
+
+  # Quota errors in line mode, as a CI/CD log would show them
+  kubectl watch-rollout demo --scenario=quota -o line --until-complete`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return monitor.Classify(monitor.ErrInvalidArguments, cobra.NoArgs(cmd, args))
+		},
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runDemo(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.scenario, "scenario", string(simulator.ScenarioSuccess),
+		"Failure to inject into the new revision: "+joinScenarios(", "))
+	cmd.Flags().Int32Var(&opts.replicas, "replicas", defaults.Replicas,
+		"Desired replicas of the simulated deployment")
+	cmd.Flags().StringVar(&opts.maxSurge, "max-surge", "",
+		"RollingUpdate maxSurge, a pod count or percentage (default 25%)")
+	cmd.Flags().StringVar(&opts.maxUnavailable, "max-unavailable", "",
+		"RollingUpdate maxUnavailable, a pod count or percentage (default 25%)")
+	cmd.Flags().DurationVar(&opts.step, "step", defaults.Step,
+		"Simulated time between controller and kubelet steps")
+	cmd.Flags().IntVar(&opts.startAfter, "start-after", defaults.StartAfter,
+		"Steps to run the previous revision before the rollout starts")
+	cmd.Flags().DurationVar(&opts.progressDeadline, "progress-deadline", defaults.ProgressDeadline,
+		"Progress deadline of the simulated deployment")
+	cmd.Flags().BoolVar(&opts.untilComplete, "until-complete", false,
+		"Exit after the simulated rollout finishes (default: keep watching)")
+	cmd.Flags().BoolVar(&opts.rollbackOnFailure, "rollback-on-failure", false,
+		"With --until-complete, roll the failed rollout back and watch the rollback")
+	cmd.Flags().BoolVar(&opts.lineMode, "line-mode", false,
+		"Use line-based output format (same as --output=line)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", string(monitor.OutputTUI),
+		"Output format: "+joinOutputFormats(", "))
+	cmd.Flags().StringVar(&opts.metricsAddr, "metrics-addr", "",
+		"Serve Prometheus metrics of the simulated rollout at /metrics on this address (e.g., :9090)")
+	cmd.Flags().StringVar(&opts.historyFile, "history-file", "",
+		"Record simulated rollouts in this history file, e.g. to try --eta-model=history across runs (default: none)")
+	addETAModelFlag(cmd, &opts.etaModel)
+	addNotifyFlags(cmd, &opts.notify)
+
+	return cmd
+}
+
+// joinScenarios lists the supported demo scenarios separated by sep.
+func joinScenarios(sep string) string {
+	names := make([]string, 0, len(simulator.Scenarios))
+	for _, scenario := range simulator.Scenarios {
+		names = append(names, string(scenario))
+	}
+
+	return strings.Join(names, sep)
+}
+
+// parseIntOrPercent parses a pod count or percentage flag, empty values select the K8s default.
+func parseIntOrPercent(flag, value string) (*intstr.IntOrString, error) {
+	if value == "" {
+		return nil, nil //nolint:nilnil // nil selects the default
+	}
+
+	parsed := intstr.Parse(value)
+	if parsed.Type == intstr.String && !strings.HasSuffix(value, "%") {
+		return nil, fmt.Errorf("--%s must be a pod count or percentage (e.g., 1 or 25%%), got '%s'", flag, value)
+	}
+
+	return &parsed, nil
+}
+
+// simulatorOptions converts the demo flags into simulator options.
+func simulatorOptions(opts demoOptions) (simulator.Options, error) {
+	simOpts := simulator.DefaultOptions()
+	simOpts.Scenario = simulator.Scenario(opts.scenario)
+
+	if !slices.Contains(simulator.Scenarios, simOpts.Scenario) {
+		return simOpts, fmt.Errorf("unsupported --scenario '%s' (use: %s)", opts.scenario, joinScenarios(", "))
+	}
+
+	simOpts.Replicas = opts.replicas
+	simOpts.Step = opts.step
+	simOpts.StartAfter = opts.startAfter
+	simOpts.ProgressDeadline = opts.progressDeadline
+
+	var err error
+
+	simOpts.MaxSurge, err = parseIntOrPercent("max-surge", opts.maxSurge)
+	if err != nil {
+		return simOpts, err
+	}
+
+	simOpts.MaxUnavailable, err = parseIntOrPercent("max-unavailable", opts.maxUnavailable)
+	if err != nil {
+		return simOpts, err
+	}
+
+	err = simOpts.Validate()
+	if err != nil {
+		return simOpts, fmt.Errorf("invalid demo options: %w", err)
+	}
+
+	return simOpts, nil
+}
+
+// runDemo watches a simulated rollout until it finishes or the user quits.
+func runDemo(opts demoOptions) error {
+	if opts.rollbackOnFailure && !opts.untilComplete {
+		return monitor.Classify(monitor.ErrInvalidArguments, errors.New("--rollback-on-failure requires --until-complete"))
+	}
+
+	output, err := parseOutputFormat(opts.output, opts.lineMode)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	simOpts, err := simulatorOptions(opts)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	etaModel, err := parseETAModel(opts.etaModel, opts.historyFile)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	cluster, err := simulator.New(simOpts)
+	if err != nil {
+		return fmt.Errorf("failed to start simulation: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg := monitor.DefaultConfig()
+	cfg.UntilComplete = opts.untilComplete
+	cfg.RollbackOnFailure = opts.rollbackOnFailure
+	cfg.Output = output
+	cfg.MetricsAddr = opts.metricsAddr
+	cfg.ETAModel = etaModel
+
+	err = applyNotifyOptions(&cfg, opts.notify)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	if opts.historyFile != "" {
+		cfg.History, err = monitor.OpenHistory(opts.historyFile, demoCluster, simOpts.Namespace)
+		if err != nil {
+			return monitor.Classify(monitor.ErrInvalidArguments, err)
+		}
+	}
+
+	spec := monitor.TargetSpec{Targets: []monitor.Target{{Kind: types.KindDeployment, Name: simOpts.Name}}}
+
+	m, err := monitor.NewWithConfig(cluster.Source(), spec, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize monitoring: %w", err)
+	}
+
+	simCtx, stopSim := context.WithCancelCause(ctx)
+	defer stopSim(nil)
+
+	go func() {
+		err := cluster.Run(simCtx)
+		if err != nil {
+			stopSim(err)
+		}
+	}()
+
+	err = m.Run(simCtx)
+	warnNotificationError(m)
+	warnHistoryError(cfg.History)
+
+	if cause := context.Cause(simCtx); cause != nil && !errors.Is(cause, context.Canceled) {
+		return fmt.Errorf("simulation failed: %w", cause)
+	}
+
+	if err != nil {
+		return fmt.Errorf("monitoring failed: %w", err)
+	}
+
+	return nil
+}
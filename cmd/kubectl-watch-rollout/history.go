@@ -0,0 +1,176 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// historyOptions holds values of the history command's flags.
+type historyOptions struct {
+	limit       int
+	historyFile string
+}
+
+// addHistoryFileFlag registers --history-file, defaulting to the history in the user's configuration directory.
+func addHistoryFileFlag(cmd *cobra.Command, path *string) {
+	defaultPath, _ := monitor.DefaultHistoryPath() // No configuration directory leaves history disabled
+
+	cmd.Flags().StringVar(path, "history-file", defaultPath,
+		"Rollout history file finished rollouts are recorded in (empty disables history)")
+}
+
+// newHistoryCommand creates the command listing recorded rollouts of a workload.
+func newHistoryCommand() *cobra.Command {
+	configFlags := genericclioptions.NewConfigFlags(true)
+
+	var opts historyOptions
+
+	cmd := &cobra.Command{
+		Use:   "history [TYPE/]NAME",
+		Short: "List past rollouts of a workload and how long they typically take",
+		Long: `List past rollouts of a workload recorded by previous watches, with their durations and outcomes.
+
+Every rollout watched from start to finish is recorded in the history file, per cluster and namespace.
+The summary shows the median (p50) and 90th percentile (p90) duration of recent completed rollouts,
+failed rollouts and rollbacks left out. No cluster access is needed.`,
+		Example: `  # Past rollouts of a deployment
+  kubectl watch-rollout history my-deployment -n production
+
+  # The last 50 rollouts of a StatefulSet
+  kubectl watch-rollout history statefulset/db -n production --limit=50`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return monitor.Classify(monitor.ErrInvalidArguments, cobra.ExactArgs(1)(cmd, args))
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runHistory(configFlags, args[0], opts)
+		},
+	}
+
+	configFlags.AddFlags(cmd.Flags())
+	cmd.Flags().IntVar(&opts.limit, "limit", monitor.DefaultHistoryWindow,
+		"Most recent rollouts to list (0 lists all)")
+	addHistoryFileFlag(cmd, &opts.historyFile)
+
+	return cmd
+}
+
+// runHistory prints the recorded rollouts of a workload, oldest first, followed by typical durations.
+func runHistory(configFlags *genericclioptions.ConfigFlags, arg string, opts historyOptions) error {
+	if opts.limit < 0 {
+		return monitor.Classify(monitor.ErrInvalidArguments, errors.New("--limit must not be negative"))
+	}
+
+	if opts.historyFile == "" {
+		return monitor.Classify(monitor.ErrInvalidArguments, errors.New("--history-file is required"))
+	}
+
+	target, err := parseWorkloadArg(arg)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	restConfig, err := configFlags.ToRESTConfig()
+	if err != nil {
+		return monitor.Classify(monitor.ErrKubeconfig, fmt.Errorf("failed to load kubeconfig: %w", err))
+	}
+
+	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return monitor.Classify(monitor.ErrKubeconfig,
+			fmt.Errorf("failed to determine namespace (use -n flag to specify): %w", err))
+	}
+
+	history, err := monitor.OpenHistory(opts.historyFile, restConfig.Host, namespace)
+	if err != nil {
+		return err
+	}
+
+	records := history.Rollouts(target.Kind, target.Name)
+	if len(records) == 0 {
+		fmt.Fprintf(os.Stderr, "No rollouts of %s/%s recorded in namespace '%s'\n",
+			strings.ToLower(string(target.Kind)), target.Name, namespace)
+
+		return nil
+	}
+
+	summary := monitor.SummarizeHistory(records)
+
+	if opts.limit > 0 && len(records) > opts.limit {
+		records = records[len(records)-opts.limit:]
+	}
+
+	return printHistory(os.Stdout, records, summary)
+}
+
+// printHistory writes recorded rollouts as a table, followed by their typical durations.
+func printHistory(w io.Writer, records []monitor.HistoryRecord, summary types.RolloutHistory) error {
+	table := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0) //nolint:mnd // kubectl column padding
+
+	fmt.Fprintln(table, "STARTED\tREVISION\tREPLICASET\tDURATION\tOUTCOME\tWARNINGS") //nolint:errcheck // flushed below
+
+	for _, r := range records {
+		outcome := r.Outcome
+		if r.Rollback {
+			outcome += " (rollback)"
+		}
+
+		fmt.Fprintf(table, "%s\t%d\t%s\t%s\t%s\t%s\n", //nolint:errcheck // flushed below
+			r.Started.Local().Format("2006-01-02 15:04:05"), r.Revision, r.ReplicaSet,
+			types.FormatDuration(r.Duration()), outcome, historyWarnings(r.Events))
+	}
+
+	err := table.Flush()
+	if err != nil {
+		return err
+	}
+
+	if summary.Samples == 0 {
+		_, err = fmt.Fprintln(w, "\nNo completed rollouts to compute typical durations from")
+
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\nTypical duration (last %d completed rollouts): p50 %s, p90 %s\n",
+		summary.Samples, types.FormatDuration(summary.P50), types.FormatDuration(summary.P90))
+
+	return err
+}
+
+// historyWarnings summarizes the warning event clusters of a recorded rollout, e.g. "BackOff ×12".
+func historyWarnings(events []monitor.HistoryEvent) string {
+	var warnings []string
+
+	for _, e := range events {
+		if e.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, fmt.Sprintf("%s ×%d", e.Reason, e.Count))
+		}
+	}
+
+	if len(warnings) == 0 {
+		return "-"
+	}
+
+	return strings.Join(warnings, ", ")
+}
+
+// warnHistoryError reports rollouts that could not be recorded; they never fail the watch itself.
+func warnHistoryError(history *monitor.History) {
+	if history == nil {
+		return
+	}
+
+	err := history.Err()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
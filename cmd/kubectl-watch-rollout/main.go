@@ -1,6 +1,6 @@
 // Package main implements the kubectl-watch-rollout plugin.
 //
-// This kubectl plugin provides real-time monitoring of Kubernetes deployment rollouts,
+// This kubectl plugin provides real-time monitoring of Kubernetes deployment, statefulset and daemonset rollouts,
 // displaying progress bars, pod status, warnings, and completion estimates.
 package main
 
@@ -8,15 +8,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/signal"
 	"regexp"
 	"slices"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/tui"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes"
 )
@@ -30,30 +35,93 @@ var validDeploymentTypes = []string{
 	"deployment.v1.apps", "deployments.v1.apps",
 }
 
-// parseDeploymentArg extracts deployment name from argument with optional resource type prefix.
-// Supports kubectl-style specs like "deployment/my-app" or "deployments.apps/my-app".
-// Returns extracted deployment name or error if format invalid or not a deployment type.
-func parseDeploymentArg(arg string) (string, error) {
+// validStatefulSetTypes lists accepted resource type prefixes for statefulset arguments.
+var validStatefulSetTypes = []string{
+	"statefulset", "statefulsets", "sts",
+	"statefulset.apps", "statefulsets.apps",
+	"statefulset.v1.apps", "statefulsets.v1.apps",
+}
+
+// validDaemonSetTypes lists accepted resource type prefixes for daemonset arguments.
+var validDaemonSetTypes = []string{
+	"daemonset", "daemonsets", "ds",
+	"daemonset.apps", "daemonsets.apps",
+	"daemonset.v1.apps", "daemonsets.v1.apps",
+}
+
+// parseWorkloadArg extracts workload kind and name from argument with optional resource type prefix.
+// Supports kubectl-style specs like "deployment/my-app", "deployments.apps/my-app", "sts/db" or "ds/agent".
+// Arguments without a prefix are treated as deployments.
+// Returns the target or error if format invalid or the resource type is not supported.
+func parseWorkloadArg(arg string) (monitor.Target, error) {
 	if !strings.Contains(arg, "/") {
-		return arg, nil // No prefix, return as-is
+		return monitor.Target{Kind: types.KindDeployment, Name: arg}, nil // No prefix, return as-is
 	}
 
 	parts := strings.Split(arg, "/")
 	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid resource format '%s': expected TYPE/NAME (e.g., deployment/my-app)", arg)
+		return monitor.Target{}, fmt.Errorf(
+			"invalid resource format '%s': expected TYPE/NAME (e.g., deployment/my-app)", arg)
 	}
 
 	resourceType, name := parts[0], parts[1]
 
-	// Validate it's a deployment resource type
-	if !slices.Contains(validDeploymentTypes, resourceType) {
-		return "", fmt.Errorf(
-			"resource type '%s' is not a deployment (use: deployment, deployments, or deploy)",
-			resourceType,
-		)
+	switch {
+	case slices.Contains(validDeploymentTypes, resourceType):
+		return monitor.Target{Kind: types.KindDeployment, Name: name}, nil
+	case slices.Contains(validStatefulSetTypes, resourceType):
+		return monitor.Target{Kind: types.KindStatefulSet, Name: name}, nil
+	case slices.Contains(validDaemonSetTypes, resourceType):
+		return monitor.Target{Kind: types.KindDaemonSet, Name: name}, nil
+	}
+
+	return monitor.Target{}, fmt.Errorf(
+		"resource type '%s' is not supported (use: deployment, deploy, statefulset, sts, daemonset, or ds)",
+		resourceType,
+	)
+}
+
+// Exit codes, one per monitor error class. Documented in README, do not renumber.
+const (
+	exitRolloutFailed      = 1
+	exitInvalidArguments   = 2
+	exitRolledBack         = 3
+	exitWatchTimeout       = 4
+	exitWorkloadNotFound   = 5
+	exitKubeconfig         = 6
+	exitAccessDenied       = 7
+	exitClusterUnavailable = 8
+	exitError              = 9 // Any error without a class
+	exitRolloutHalted      = 10
+	exitCancelled          = 130 // 128 + SIGINT, as shells report Ctrl+C
+)
+
+// exitCodes maps error classes to exit codes, checked in order.
+var exitCodes = []struct {
+	class error
+	code  int
+}{
+	{monitor.ErrCancelled, exitCancelled},
+	{monitor.ErrRolledBack, exitRolledBack},
+	{monitor.ErrRolloutFailed, exitRolloutFailed},
+	{monitor.ErrRolloutHalted, exitRolloutHalted},
+	{monitor.ErrWatchTimeout, exitWatchTimeout},
+	{monitor.ErrInvalidArguments, exitInvalidArguments},
+	{monitor.ErrWorkloadNotFound, exitWorkloadNotFound},
+	{monitor.ErrKubeconfig, exitKubeconfig},
+	{monitor.ErrAccessDenied, exitAccessDenied},
+	{monitor.ErrClusterUnavailable, exitClusterUnavailable},
+}
+
+// exitCode returns the exit code for the class of err.
+func exitCode(err error) int {
+	for _, e := range exitCodes {
+		if errors.Is(err, e.class) {
+			return e.code
+		}
 	}
 
-	return name, nil
+	return exitError
 }
 
 func main() {
@@ -61,11 +129,17 @@ func main() {
 
 	err := cmd.Execute()
 	if err != nil {
-		if !errors.Is(err, monitor.ErrProgressDeadlineExceeded) {
+		switch {
+		case errors.Is(err, monitor.ErrRolledBack):
+			fmt.Fprintf(os.Stderr, "Rollout failed and was rolled back to the previous revision\n")
+		case errors.Is(err, monitor.ErrRolloutFailed), errors.Is(err, monitor.ErrRolloutHalted),
+			errors.Is(err, monitor.ErrCancelled):
+			// Already shown by the view, or requested by the user
+		default:
 			fmt.Fprintf(os.Stderr, "Error: %v\n\n", err)
 		}
 
-		os.Exit(1)
+		os.Exit(exitCode(err))
 	}
 }
 
@@ -73,113 +147,594 @@ func main() {
 func newRootCommand() *cobra.Command {
 	configFlags := genericclioptions.NewConfigFlags(true)
 
-	var (
-		untilComplete       bool
-		lineMode            bool
-		ignoreEvents        string
-		similarityThreshold float64
-	)
+	var opts rootOptions
 
 	cmd := &cobra.Command{
-		Use:   "kubectl watch-rollout DEPLOYMENT",
+		Use:   "kubectl watch-rollout [[TYPE/]NAME... | -l SELECTOR]",
 		Short: "Watch Kubernetes deployment rollouts with live progress updates",
-		Long: `Watch Kubernetes deployment rollouts with live progress updates and status tracking.
+		Long: `Watch Kubernetes deployment, statefulset and daemonset rollouts with live progress updates and status tracking.
 
-By default, monitors deployments continuously across multiple rollouts. Exit with Ctrl+C when done.
+By default, monitors workloads continuously across multiple rollouts. Exit with Ctrl+C when done.
 
-This command monitors your deployment rollout in real-time, showing:
-  • Progress bars for new and old ReplicaSets
+This command monitors your rollout in real-time, showing:
+  • Progress bars for new and old ReplicaSets (or StatefulSet/DaemonSet revisions)
   • Pod status counts (Available, Ready, Current)
   • Warning events and error messages
   • Estimated time to completion
   • Automatic detection of rollout success or failure
-  • Continuous monitoring across multiple rollouts (default behavior)`,
+  • Continuous monitoring across multiple rollouts (default behavior)
+
+Pass several workloads, or select deployments with -l, to get a dashboard with one row
+per workload. Select a row and press Enter to see its detailed view.
+
+Without arguments, attaches to the deployment currently rolling out in the namespace,
+offering a picker if there are several and waiting for the next rollout if there are none.`,
 		Example: `  # Continuous monitoring (default) - watches across multiple rollouts
   kubectl watch-rollout my-deployment -n production
 
   # Single-rollout mode - exit after one rollout completes (for CI/CD)
   kubectl watch-rollout my-deployment -n production --until-complete
 
+  # Give up after 15 minutes even if the progress deadline is longer (exit code 4)
+  kubectl watch-rollout my-deployment -n production --until-complete --timeout=15m
+
+  # CI/CD gate that reverts a failed rollout (exit code 3 once the rollback completes)
+  kubectl watch-rollout my-deployment -n production --until-complete --rollback-on-failure
+
   # Watch using resource type prefix (kubectl-style)
   kubectl watch-rollout deployment/my-deployment -n production
-  kubectl watch-rollout deployments.apps/my-deployment -n production`,
+  kubectl watch-rollout deployments.apps/my-deployment -n production
+
+  # Watch a StatefulSet rollout ordinal by ordinal
+  kubectl watch-rollout statefulset/db -n production
+
+  # Watch a DaemonSet rollout node by node
+  kubectl watch-rollout daemonset/node-agent -n kube-system
+
+  # Attach to whatever deployment is rolling out right now
+  kubectl watch-rollout -n production
+
+  # Dashboard of several workloads, or of all deployments matching a label selector
+  kubectl watch-rollout frontend backend statefulset/db -n production
+  kubectl watch-rollout -l app.kubernetes.io/part-of=shop -n production
+
+  # Machine-readable JSON Lines output for pipelines and log aggregators
+  kubectl watch-rollout my-deployment -n production --until-complete -o jsonl
+
+  # Post rollout transitions to a chat webhook
+  kubectl watch-rollout my-deployment -n production --notify-webhook=https://hooks.example.com/rollouts
+
+  # Past rollouts of a deployment and how long they typically take
+  kubectl watch-rollout history my-deployment -n production
+
+  # Expose rollout progress to Prometheus from a deploy runner
+  kubectl watch-rollout my-deployment -n production --until-complete -o line --metrics-addr=:9090`,
 		Version:           version,
-		Args:              cobra.ExactArgs(1),
+		Args:              cobra.ArbitraryArgs,
 		SilenceUsage:      true,
 		SilenceErrors:     true,
 		DisableAutoGenTag: true,
 		RunE: func(_ *cobra.Command, args []string) error {
-			return runMonitor(configFlags, args[0], untilComplete, lineMode, ignoreEvents, similarityThreshold)
+			return runMonitor(configFlags, args, opts)
 		},
 	}
 
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	})
+
 	configFlags.AddFlags(cmd.Flags())
-	cmd.Flags().BoolVar(&untilComplete, "until-complete", false,
+	cmd.Flags().BoolVar(&opts.untilComplete, "until-complete", false,
 		"Exit after monitoring one rollout to completion (default: continuous monitoring)")
-	cmd.Flags().BoolVar(&lineMode, "line-mode", false,
-		"Use line-based output format suitable for log aggregation (default: interactive mode)")
-	cmd.Flags().StringVar(&ignoreEvents, "ignore-events", "",
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0,
+		"Stop watching after this long (e.g., 15m) and report where rollouts are stuck (default: no timeout)")
+	cmd.Flags().BoolVar(&opts.rollbackOnFailure, "rollback-on-failure", false,
+		"With --until-complete, roll a failed deployment back to its previous revision and watch the rollback")
+	cmd.Flags().Int64Var(&opts.match.Revision, "revision", 0,
+		"Wait for this deployment revision and report earlier rollouts as pending")
+	cmd.Flags().StringVar(&opts.match.Image, "image", "",
+		"Wait for a rollout running this image (e.g., repo:tag) and report earlier rollouts as pending")
+	cmd.Flags().Int64Var(&opts.match.Generation, "generation", 0,
+		"Wait until the controller observes this deployment generation")
+	cmd.Flags().StringVar(&opts.onPaused, "on-paused", string(monitor.HaltWait),
+		"With --until-complete, how to treat a paused deployment: "+joinHaltPolicies(", "))
+	cmd.Flags().StringVar(&opts.onReplicaFailure, "on-replica-failure", string(monitor.HaltWait),
+		"With --until-complete, how to treat a deployment unable to create pods (e.g., quota exceeded): "+
+			joinHaltPolicies(", "))
+	cmd.Flags().BoolVar(&opts.lineMode, "line-mode", false,
+		"Use line-based output format suitable for log aggregation (same as --output=line)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", string(monitor.OutputTUI),
+		"Output format: "+joinOutputFormats(", ")+" (jsonl emits one JSON object per update)")
+	cmd.Flags().StringVar(&opts.ignoreEvents, "ignore-events", "",
 		"Ignore events matching the specified regular expression (matched against \"Reason: Message\")")
-	cmd.Flags().Float64Var(&similarityThreshold, "similarity-threshold", monitor.DefaultSimilarityThreshold,
+	cmd.Flags().Float64Var(&opts.similarityThreshold, "similarity-threshold", monitor.DefaultSimilarityThreshold,
 		"Event clustering threshold (0.0-1.0, token match ratio, lower = more aggressive)")
+	cmd.Flags().Int64Var(&opts.logLines, "log-lines", monitor.DefaultLogTailLines,
+		"Recent log lines to fetch and cluster per failing container (0 disables log collection)")
+	cmd.Flags().StringVarP(&opts.selector, "selector", "l", "",
+		"Watch all deployments matching this label selector (e.g., app.kubernetes.io/part-of=shop)")
+	cmd.Flags().StringVar(&opts.record, "record", "",
+		"Record every update with the objects it was built from to this file, for `watch-rollout replay`")
+	cmd.Flags().StringVar(&opts.metricsAddr, "metrics-addr", "",
+		"Serve Prometheus metrics of the watched rollouts at /metrics on this address (e.g., :9090)")
+	addHistoryFileFlag(cmd, &opts.historyFile)
+	addETAModelFlag(cmd, &opts.etaModel)
+	addNotifyFlags(cmd, &opts.notify)
+
+	cmd.AddCommand(newReplayCommand())
+	cmd.AddCommand(newDemoCommand())
+	cmd.AddCommand(newHistoryCommand())
+
+	return cmd
+}
+
+// replayOptions holds values of the replay command's flags.
+type replayOptions struct {
+	speed    float64
+	lineMode bool
+	output   string
+}
+
+// newReplayCommand creates the command replaying a session recorded with --record.
+func newReplayCommand() *cobra.Command {
+	var opts replayOptions
+
+	cmd := &cobra.Command{
+		Use:   "replay FILE",
+		Short: "Replay a rollout session recorded with --record",
+		Long: `Replay a rollout session recorded with --record through the TUI, line or JSON Lines view.
+
+Updates are paced as they were recorded, or faster with --speed. No cluster access is needed.`,
+		Example: `  # Replay a recording attached to a postmortem, ten times faster
+  kubectl watch-rollout replay session.jsonl --speed=10
+
+  # Print the recorded session in line mode, without delays
+  kubectl watch-rollout replay session.jsonl -o line --speed=0`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return monitor.Classify(monitor.ErrInvalidArguments, cobra.ExactArgs(1)(cmd, args))
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runReplay(args[0], opts)
+		},
+	}
+
+	cmd.Flags().Float64Var(&opts.speed, "speed", 1,
+		"Playback speed multiplier (0 replays without delays)")
+	cmd.Flags().BoolVar(&opts.lineMode, "line-mode", false,
+		"Use line-based output format (same as --output=line)")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", string(monitor.OutputTUI),
+		"Output format: "+joinOutputFormats(", "))
 
 	return cmd
 }
 
-// runMonitor executes the deployment rollout monitoring.
-func runMonitor(
-	configFlags *genericclioptions.ConfigFlags,
-	deploymentArg string,
-	untilComplete, lineMode bool,
-	ignoreEvents string,
-	similarityThreshold float64,
-) error {
+// runReplay replays a recorded session file.
+func runReplay(path string, opts replayOptions) error {
+	if opts.speed < 0 {
+		return monitor.Classify(monitor.ErrInvalidArguments, errors.New("--speed must not be negative"))
+	}
+
+	output, err := parseOutputFormat(opts.output, opts.lineMode)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, fmt.Errorf("failed to open session recording: %w", err))
+	}
+	defer file.Close() //nolint:errcheck // read-only file, close errors not actionable
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	cfg := monitor.DefaultConfig()
+	cfg.Output = output
+
+	err = monitor.Replay(ctx, file, cfg, opts.speed)
+	if err != nil {
+		return fmt.Errorf("replay failed: %w", err)
+	}
+
+	return nil
+}
+
+// rootOptions holds values of the root command's monitoring flags.
+type rootOptions struct {
+	untilComplete       bool
+	rollbackOnFailure   bool
+	onPaused            string
+	match               monitor.RolloutMatch
+	onReplicaFailure    string
+	timeout             time.Duration
+	lineMode            bool
+	output              string
+	ignoreEvents        string
+	similarityThreshold float64
+	logLines            int64
+	selector            string
+	record              string
+	metricsAddr         string
+	historyFile         string
+	etaModel            string
+	notify              notifyOptions
+}
+
+// notifyOptions holds values of the webhook notification flags.
+type notifyOptions struct {
+	webhook      string
+	templateFile string
+	contentType  string
+	stallTimeout time.Duration
+}
+
+// addNotifyFlags registers the webhook notification flags.
+func addNotifyFlags(cmd *cobra.Command, opts *notifyOptions) {
+	cmd.Flags().StringVar(&opts.webhook, "notify-webhook", "",
+		"POST rollout started, warning, stalled, complete and deadline exceeded notifications to this URL")
+	cmd.Flags().StringVar(&opts.templateFile, "notify-template", "",
+		"Go template file rendering the webhook payload (default: JSON with a chat-friendly \"text\" field)")
+	cmd.Flags().StringVar(&opts.contentType, "notify-content-type", "",
+		"Content-Type of webhook requests (default: application/json for the default template, none otherwise)")
+	cmd.Flags().DurationVar(&opts.stallTimeout, "notify-stalled-after", monitor.DefaultStallSeconds*time.Second,
+		"Notify that a rollout stalled after this long without progress")
+}
+
+// applyNotifyOptions validates the webhook notification flags and sets them in the config.
+func applyNotifyOptions(cfg *monitor.Config, opts notifyOptions) error {
+	if opts.webhook == "" {
+		if opts.templateFile != "" {
+			return errors.New("--notify-template requires --notify-webhook")
+		}
+
+		if opts.contentType != "" {
+			return errors.New("--notify-content-type requires --notify-webhook")
+		}
+
+		return nil
+	}
+
+	webhook, err := url.Parse(opts.webhook)
+	if err != nil || (webhook.Scheme != "http" && webhook.Scheme != "https") || webhook.Host == "" {
+		return fmt.Errorf("--notify-webhook must be an http or https URL, got '%s'", opts.webhook)
+	}
+
+	if opts.stallTimeout <= 0 {
+		return errors.New("--notify-stalled-after must be positive")
+	}
+
+	cfg.NotifyWebhook = opts.webhook
+	cfg.NotifyContentType = opts.contentType
+	cfg.StallTimeout = opts.stallTimeout
+
+	if opts.templateFile != "" {
+		raw, err := os.ReadFile(opts.templateFile)
+		if err != nil {
+			return fmt.Errorf("failed to read notification template: %w", err)
+		}
+
+		cfg.NotifyTemplate = string(raw)
+
+		_, err = monitor.ParseNotifyTemplate(cfg.NotifyTemplate)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// warnNotificationError reports webhook notifications that failed; they never fail the watch itself.
+func warnNotificationError(m *monitor.Controller) {
+	err := m.NotificationError()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// addETAModelFlag registers --eta-model.
+func addETAModelFlag(cmd *cobra.Command, model *string) {
+	cmd.Flags().StringVar(model, "eta-model", string(monitor.ETALinear),
+		"How to estimate completion: "+joinETAModels(", ")+
+			" (history starts from the typical duration of previous rollouts)")
+}
+
+// joinETAModels lists the supported ETA models separated by sep.
+func joinETAModels(sep string) string {
+	names := make([]string, 0, len(monitor.ETAModels))
+	for _, model := range monitor.ETAModels {
+		names = append(names, string(model))
+	}
+
+	return strings.Join(names, sep)
+}
+
+// parseETAModel validates --eta-model; the history model needs the rollout history.
+func parseETAModel(value, historyFile string) (monitor.ETAModel, error) {
+	model := monitor.ETAModel(value)
+	if !slices.Contains(monitor.ETAModels, model) {
+		return "", fmt.Errorf("unsupported --eta-model '%s' (use: %s)", value, joinETAModels(", "))
+	}
+
+	if model == monitor.ETAHistory && historyFile == "" {
+		return "", errors.New("--eta-model=history requires --history-file")
+	}
+
+	return model, nil
+}
+
+// joinOutputFormats lists the supported output formats separated by sep.
+func joinOutputFormats(sep string) string {
+	names := make([]string, 0, len(monitor.OutputFormats))
+	for _, format := range monitor.OutputFormats {
+		names = append(names, string(format))
+	}
+
+	return strings.Join(names, sep)
+}
+
+// joinHaltPolicies lists the supported halt policies separated by sep.
+func joinHaltPolicies(sep string) string {
+	names := make([]string, 0, len(monitor.HaltPolicies))
+	for _, policy := range monitor.HaltPolicies {
+		names = append(names, string(policy))
+	}
+
+	return strings.Join(names, sep)
+}
+
+// parseHaltPolicy validates the value of a halt policy flag.
+func parseHaltPolicy(flag, value string) (monitor.HaltPolicy, error) {
+	policy := monitor.HaltPolicy(value)
+	if !slices.Contains(monitor.HaltPolicies, policy) {
+		return "", fmt.Errorf("unsupported --%s policy '%s' (use: %s)", flag, value, joinHaltPolicies(", "))
+	}
+
+	return policy, nil
+}
+
+// parseOutputFormat resolves the output format from --output and the legacy --line-mode flag.
+func parseOutputFormat(output string, lineMode bool) (monitor.OutputFormat, error) {
+	format := monitor.OutputFormat(output)
+	if !slices.Contains(monitor.OutputFormats, format) {
+		return "", fmt.Errorf("unsupported output format '%s' (use: %s)", output, joinOutputFormats(", "))
+	}
+
+	if lineMode {
+		if format != monitor.OutputTUI && format != monitor.OutputLine {
+			return "", fmt.Errorf("--line-mode cannot be combined with --output=%s", output)
+		}
+
+		return monitor.OutputLine, nil
+	}
+
+	return format, nil
+}
+
+// validateRolloutMatch checks --revision, --image and --generation against the monitored workloads.
+func validateRolloutMatch(match monitor.RolloutMatch, spec monitor.TargetSpec) error {
+	if match.Revision < 0 || match.Generation < 0 {
+		return errors.New("--revision and --generation must be positive")
+	}
+
+	if match.IsZero() {
+		return nil
+	}
+
+	for _, target := range spec.Targets {
+		if target.Kind != types.KindDeployment {
+			return fmt.Errorf("--revision, --image and --generation only apply to deployments, not %s '%s'",
+				strings.ToLower(string(target.Kind)), target.Name)
+		}
+	}
+
+	return nil
+}
+
+// parseTargetSpec builds the monitoring target spec from positional arguments and --selector.
+func parseTargetSpec(args []string, selector string) (monitor.TargetSpec, error) {
+	var spec monitor.TargetSpec
+
+	for _, arg := range args {
+		target, err := parseWorkloadArg(arg)
+		if err != nil {
+			return monitor.TargetSpec{}, err
+		}
+
+		spec.Targets = append(spec.Targets, target)
+	}
+
+	if selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return monitor.TargetSpec{}, fmt.Errorf("invalid label selector '%s': %w", selector, err)
+		}
+
+		spec.Selector = parsed
+	}
+
+	return spec, nil
+}
+
+// discoverTarget attaches to the deployment currently rolling out in the namespace.
+// Several candidates are offered in an interactive picker (TUI mode only).
+func discoverTarget(
+	ctx context.Context,
+	repo monitor.RolloutSource,
+	namespace string,
+	output monitor.OutputFormat,
+) (monitor.Target, error) {
+	choose := func(names []string) (string, error) {
+		if !output.IsInteractive() {
+			return "", monitor.Classify(monitor.ErrInvalidArguments, fmt.Errorf(
+				"several rollouts in progress in namespace '%s' (%s): specify one",
+				namespace, strings.Join(names, ", ")))
+		}
+
+		name, err := tui.Pick(fmt.Sprintf("Several rollouts in progress in namespace '%s', choose one:", namespace), names)
+		if errors.Is(err, tui.ErrPickerCancelled) {
+			return "", monitor.Classify(monitor.ErrCancelled, err)
+		}
+
+		return name, err
+	}
+
+	onWait := func() {
+		fmt.Fprintf(os.Stderr, "Waiting for a rollout to start in namespace '%s'...\n", namespace)
+	}
+
+	return monitor.DiscoverRollout(ctx, repo, choose, onWait)
+}
+
+// runMonitor executes the workload rollout monitoring.
+// Flags are validated before the kubeconfig is loaded, so invalid arguments are reported as such.
+func runMonitor(configFlags *genericclioptions.ConfigFlags, args []string, opts rootOptions) error {
+	cfg, spec, err := parseMonitorOptions(args, opts)
+	if err != nil {
+		return monitor.Classify(monitor.ErrInvalidArguments, err)
+	}
+
+	if opts.record != "" {
+		file, err := os.Create(opts.record)
+		if err != nil {
+			return monitor.Classify(monitor.ErrInvalidArguments, fmt.Errorf("failed to create session recording: %w", err))
+		}
+		defer file.Close() //nolint:errcheck // snapshots are written unbuffered, close errors not actionable
+
+		cfg.Record = file
+	}
+
 	restConfig, err := configFlags.ToRESTConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load kubeconfig: %w", err)
+		return monitor.Classify(monitor.ErrKubeconfig, fmt.Errorf("failed to load kubeconfig: %w", err))
 	}
 
 	clientset, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return fmt.Errorf("failed to connect to Kubernetes cluster (check cluster access and credentials): %w", err)
+		return monitor.Classify(monitor.ErrKubeconfig,
+			fmt.Errorf("failed to connect to Kubernetes cluster (check cluster access and credentials): %w", err))
 	}
 
 	namespace, _, err := configFlags.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
-		return fmt.Errorf("failed to determine namespace (use -n flag to specify): %w", err)
+		return monitor.Classify(monitor.ErrKubeconfig,
+			fmt.Errorf("failed to determine namespace (use -n flag to specify): %w", err))
 	}
 
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
-	defer cancel()
+	if opts.historyFile != "" {
+		cfg.History, err = monitor.OpenHistory(opts.historyFile, restConfig.Host, namespace)
+		if err != nil && cfg.ETAModel == monitor.ETAHistory {
+			return monitor.Classify(monitor.ErrInvalidArguments, fmt.Errorf("--eta-model=history: %w", err))
+		}
 
-	deploymentName, err := parseDeploymentArg(deploymentArg)
-	if err != nil {
-		return err
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v, rollouts will not be recorded\n", err)
+		}
 	}
 
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
 	repo := monitor.NewDeploymentRepository(clientset, namespace)
+	repo.SetSyncRetryHandler(func(err error) {
+		fmt.Fprintf(os.Stderr, "Cannot watch resources in namespace '%s', retrying: %v\n", namespace, err)
+	})
 
-	cfg := monitor.DefaultConfig()
-	cfg.UntilComplete = untilComplete
-	cfg.LineMode = lineMode
-	cfg.SimilarityThreshold = similarityThreshold
+	// The timeout also covers waiting for a rollout to be discovered
+	if opts.timeout > 0 {
+		var cancelTimeout context.CancelFunc
 
-	if ignoreEvents != "" {
-		cfg.IgnoreEvents, err = regexp.Compile(ignoreEvents)
+		ctx, cancelTimeout = monitor.WithWatchTimeout(ctx, opts.timeout)
+		defer cancelTimeout()
+	}
+
+	if len(spec.Targets) == 0 && spec.Selector == nil {
+		target, err := discoverTarget(ctx, repo, namespace, cfg.Output)
 		if err != nil {
-			return fmt.Errorf("failed to parse regular expression: %w", err)
+			return err
 		}
+
+		spec.Targets = []monitor.Target{target}
 	}
 
-	m, err := monitor.NewWithConfig(repo, deploymentName, cfg)
+	m, err := monitor.NewWithConfig(repo, spec, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize monitoring: %w", err)
 	}
 
 	err = m.Run(ctx)
+	warnNotificationError(m)
+	warnHistoryError(cfg.History)
+
 	if err != nil {
 		return fmt.Errorf("monitoring failed: %w", err)
 	}
 
 	return nil
 }
+
+// parseMonitorOptions validates the flags and arguments of the root command and
+// builds the monitor configuration and target spec from them.
+func parseMonitorOptions(args []string, opts rootOptions) (monitor.Config, monitor.TargetSpec, error) {
+	cfg := monitor.DefaultConfig()
+
+	if opts.rollbackOnFailure && !opts.untilComplete {
+		return cfg, monitor.TargetSpec{}, errors.New("--rollback-on-failure requires --until-complete")
+	}
+
+	spec, err := parseTargetSpec(args, opts.selector)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	output, err := parseOutputFormat(opts.output, opts.lineMode)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	err = validateRolloutMatch(opts.match, spec)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	onPaused, err := parseHaltPolicy("on-paused", opts.onPaused)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	onReplicaFailure, err := parseHaltPolicy("on-replica-failure", opts.onReplicaFailure)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	etaModel, err := parseETAModel(opts.etaModel, opts.historyFile)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	cfg.UntilComplete = opts.untilComplete
+	cfg.RollbackOnFailure = opts.rollbackOnFailure
+	cfg.Match = opts.match
+	cfg.OnPaused = onPaused
+	cfg.OnReplicaFailure = onReplicaFailure
+	cfg.Timeout = opts.timeout
+	cfg.Output = output
+	cfg.SimilarityThreshold = opts.similarityThreshold
+	cfg.LogTailLines = opts.logLines
+	cfg.MetricsAddr = opts.metricsAddr
+	cfg.ETAModel = etaModel
+
+	err = applyNotifyOptions(&cfg, opts.notify)
+	if err != nil {
+		return cfg, spec, err
+	}
+
+	if opts.ignoreEvents != "" {
+		cfg.IgnoreEvents, err = regexp.Compile(opts.ignoreEvents)
+		if err != nil {
+			return cfg, spec, fmt.Errorf("failed to parse regular expression: %w", err)
+		}
+	}
+
+	return cfg, spec, nil
+}
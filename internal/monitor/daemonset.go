@@ -0,0 +1,171 @@
+package monitor
+
+// This file contains DaemonSet rollout status and snapshot construction.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultDaemonSetMaxUnavailable is the K8s default for DaemonSet RollingUpdate strategy
+	defaultDaemonSetMaxUnavailable = "1"
+	// defaultDaemonSetMaxSurge is the K8s default for DaemonSet RollingUpdate strategy
+	defaultDaemonSetMaxSurge = "0"
+)
+
+// CalculateDaemonSetStatus determines rollout status from DaemonSet status fields.
+// Mirrors kubectl rollout status: a rollout is complete once every scheduled node
+// runs an updated pod and all of them are available. DaemonSets have no progress deadline.
+func CalculateDaemonSetStatus(ds *appsv1.DaemonSet) types.RolloutStatus {
+	status := ds.Status
+
+	if status.ObservedGeneration < ds.Generation {
+		return types.StatusProgressing
+	}
+
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled {
+		return types.StatusProgressing
+	}
+
+	if status.NumberAvailable < status.DesiredNumberScheduled {
+		return types.StatusProgressing
+	}
+
+	return types.StatusComplete
+}
+
+// daemonSetUpdatedAvailable estimates the available updated pods from DaemonSet status, which has
+// no such count: all available pods once every node is updated, otherwise at most the updated ones.
+// It reaches desiredNumberScheduled when the counts CalculateDaemonSetStatus checks are complete.
+func daemonSetUpdatedAvailable(ds *appsv1.DaemonSet) int32 {
+	return min(ds.Status.UpdatedNumberScheduled, ds.Status.NumberAvailable)
+}
+
+// daemonSetStrategyParams returns maxSurge and maxUnavailable for a DaemonSet, applying K8s defaults.
+func daemonSetStrategyParams(ds *appsv1.DaemonSet) strategyParams {
+	if ds.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return strategyParams{}
+	}
+
+	params := strategyParams{
+		maxSurge:       defaultDaemonSetMaxSurge,
+		maxUnavailable: defaultDaemonSetMaxUnavailable,
+	}
+
+	rollingUpdate := ds.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil {
+		return params
+	}
+
+	if rollingUpdate.MaxSurge != nil {
+		params.maxSurge = formatIntOrPercent(*rollingUpdate.MaxSurge)
+	}
+
+	if rollingUpdate.MaxUnavailable != nil {
+		params.maxUnavailable = formatIntOrPercent(*rollingUpdate.MaxUnavailable)
+	}
+
+	return params
+}
+
+// orderByNode sorts pod infos by node name so each grid cell maps to a stable node.
+// During surge two pods may share a node; the updated one is shown first.
+func orderByNode(infos []types.PodInfo) []types.PodInfo {
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].Node != infos[j].Node {
+			return infos[i].Node < infos[j].Node
+		}
+
+		return infos[i].New && !infos[j].New
+	})
+
+	return infos
+}
+
+// buildDaemonSetSnapshot constructs a RolloutSnapshot for a DaemonSet.
+// The update revision is the newest ControllerRevision; pods are split into NEW/OLD
+// by comparing their controller-revision-hash label with that revision's hash.
+// Progress follows DaemonSet status like CalculateDaemonSetStatus, not the pod split.
+func (c *Controller) buildDaemonSetSnapshot(ctx context.Context, t *rolloutTracker) (*types.RolloutSnapshot, error) {
+	ds, err := c.repo.GetDaemonSet(ctx, t.target.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daemonset: %w", err)
+	}
+
+	// OnDelete only updates pods as they are deleted by hand, so there is no rollout to follow
+	if ds.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return nil, fmt.Errorf("%w, daemonset uses %s", ErrUnsupportedStrategy, ds.Spec.UpdateStrategy.Type)
+	}
+
+	revision, err := c.repo.GetNewestControllerRevision(ctx, ds.Spec.Selector, ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch update revision: %w", err)
+	}
+
+	if revision == nil {
+		return nil, fmt.Errorf("%w: no update revision found for daemonset", errSnapshotPending)
+	}
+
+	pods, err := c.repo.GetPods(ctx, ds.Spec.Selector, ds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pods: %w", err)
+	}
+
+	now := time.Now()
+	desired := ds.Status.DesiredNumberScheduled
+	updateHash := revision.Labels[appsv1.ControllerRevisionHashLabelKey]
+	isNew := func(pod *corev1.Pod) bool {
+		return pod.Labels[appsv1.ControllerRevisionHashLabelKey] == updateHash
+	}
+	breakdown := breakdownPods(pods, isNew, ds.Spec.MinReadySeconds, now)
+
+	err = c.attachPodEvents(ctx, breakdown.infos, pods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pod events: %w", err)
+	}
+
+	rawEvents, err := c.collectEvents(ctx, breakdown.newPods, revision.CreationTimestamp.Time,
+		eventObject{kind: "DaemonSet", obj: ds})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+
+	c.capture(t,
+		sessionObjects("DaemonSet", ds),
+		sessionObjects("ControllerRevision", revision),
+		sessionObjects("Pod", pods...),
+		sessionEvents(rawEvents))
+
+	params := daemonSetStrategyParams(ds)
+
+	return &types.RolloutSnapshot{
+		Kind:               types.KindDaemonSet,
+		WorkloadName:       ds.Name,
+		NewRSName:          revision.Name,
+		Revision:           revision.Revision,
+		StrategyType:       string(ds.Spec.UpdateStrategy.Type),
+		MaxSurge:           params.maxSurge,
+		MaxUnavailable:     params.maxUnavailable,
+		Desired:            desired,
+		NewRS:              breakdown.newState,
+		OldRS:              breakdown.oldState,
+		Pods:               orderByNode(breakdown.infos),
+		NewProgress:        calculateProgress(daemonSetUpdatedAvailable(ds), desired),
+		OldProgress:        calculateProgress(breakdown.oldState.Available, desired),
+		StartTime:          revision.CreationTimestamp.Time,
+		SnapshotTime:       now,
+		ProgressUpdateTime: latestReadyTime(breakdown.newPods),
+		Status:             CalculateDaemonSetStatus(ds),
+		StatusMessage:      generationMessage(ds.Status.ObservedGeneration, ds.Generation),
+		Events:             SummarizeEvents(rawEvents, c.config.IgnoreEvents, c.config.SimilarityThreshold),
+		Problems:           DiagnoseContainers(breakdown.newPods),
+		Logs:               c.summarizeFailingLogs(ctx, t, breakdown.newPods),
+	}, nil
+}
@@ -0,0 +1,80 @@
+package monitor
+
+// This file contains the final diagnosis printed when the watch times out.
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxDiagnosisItems limits problems and warnings listed per rollout in the diagnosis.
+const maxDiagnosisItems = 3
+
+// diagnoseStuck describes where each unfinished rollout was stuck, one indented block per workload:
+// its status and replica counts, then its most widespread container problems and warning events.
+func diagnoseStuck(pending map[Target]*types.RolloutSnapshot, now time.Time) string {
+	targets := make([]Target, 0, len(pending))
+	for target := range pending {
+		targets = append(targets, target)
+	}
+
+	slices.SortFunc(targets, func(a, b Target) int {
+		return strings.Compare(string(a.Kind)+"/"+a.Name, string(b.Kind)+"/"+b.Name)
+	})
+
+	var lines []string
+
+	for _, target := range targets {
+		name := strings.ToLower(string(target.Kind)) + "/" + target.Name
+
+		s := pending[target]
+		if s == nil {
+			lines = append(lines, fmt.Sprintf("  %s: no rollout data received", name))
+
+			continue
+		}
+
+		if s.Waiting {
+			lines = append(lines, fmt.Sprintf("  %s: no rollout data received, %s", name, s.StatusMessage))
+
+			continue
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s: %s for %s, NEW %d/%d available, OLD %d/%d available",
+			name, formatStatus(s.Status), types.FormatDuration(now.Sub(s.StartTime)),
+			s.NewRS.Available, s.UpdateTarget(), s.OldRS.Available, s.Desired))
+
+		if s.StatusMessage != "" {
+			lines = append(lines, "    "+s.StatusMessage)
+		}
+
+		for _, p := range s.Problems[:min(len(s.Problems), maxDiagnosisItems)] {
+			lines = append(lines, fmt.Sprintf("    ✗ %s [%s] (%s)",
+				p.Reason, p.Container, strings.Join(problemDetails(p), ", ")))
+		}
+
+		warnings := 0
+
+		for _, c := range s.Events.Clusters {
+			if c.Type != corev1.EventTypeWarning || warnings == maxDiagnosisItems {
+				continue
+			}
+
+			warnings++
+
+			lines = append(lines, fmt.Sprintf("    %s %s: %s (%d exemplars)",
+				c.Symbol(), c.Reason, truncateMessage(c.Message, maxMessageLength), c.ExemplarCount))
+		}
+
+		if s.StatusMessage == "" && len(s.Problems) == 0 && warnings == 0 {
+			lines = append(lines, "    no container problems or warning events reported")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,81 @@
+package monitor
+
+// This file contains auto-discovery of the rollout to watch when no workload is given.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// ChooseFunc picks one deployment out of several with rollouts in progress.
+type ChooseFunc func(names []string) (string, error)
+
+// DiscoverRollout finds the deployment whose rollout is currently in progress.
+// If exactly one is progressing it is returned; if several are, choose decides.
+// When nothing is in flight, onWait is called once and DiscoverRollout blocks
+// until a rollout starts in the namespace or ctx is cancelled. A ctx cancelled
+// with ErrWatchTimeout as its cause returns ErrWatchTimeout instead of ErrCancelled.
+func DiscoverRollout(
+	ctx context.Context,
+	repo RolloutSource,
+	choose ChooseFunc,
+	onWait func(),
+) (Target, error) {
+	err := repo.Start(ctx, types.KindDeployment)
+	if err != nil && errors.Is(context.Cause(ctx), ErrWatchTimeout) {
+		return Target{}, fmt.Errorf("%w before the watch started: %v", ErrWatchTimeout, err)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return Target{}, discoveryStopped(ctx)
+		}
+
+		return Target{}, fmt.Errorf("failed to start watching: %w", err)
+	}
+
+	waiting := false
+
+	for {
+		names, err := repo.FindActiveRollouts(ctx)
+		if err != nil {
+			return Target{}, err
+		}
+
+		switch len(names) {
+		case 0:
+			if !waiting && onWait != nil {
+				onWait()
+			}
+
+			waiting = true
+		case 1:
+			return Target{Kind: types.KindDeployment, Name: names[0]}, nil
+		default:
+			name, err := choose(names)
+			if err != nil {
+				return Target{}, err
+			}
+
+			return Target{Kind: types.KindDeployment, Name: name}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return Target{}, discoveryStopped(ctx)
+		case <-repo.Updates():
+		}
+	}
+}
+
+// discoveryStopped returns the error for a context cancelled while waiting for a rollout.
+func discoveryStopped(ctx context.Context) error {
+	if errors.Is(context.Cause(ctx), ErrWatchTimeout) {
+		return fmt.Errorf("%w: no rollout started", ErrWatchTimeout)
+	}
+
+	return ErrCancelled
+}
@@ -0,0 +1,88 @@
+package monitor
+
+// This file contains the error classes returned by monitoring, one per exit code.
+// Errors keep their descriptive messages; match the class with errors.Is.
+
+import (
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var (
+	// ErrRolloutFailed indicates a monitored rollout failed
+	ErrRolloutFailed = errors.New("rollout failed")
+	// ErrProgressDeadlineExceeded indicates rollout failed due to progress deadline
+	ErrProgressDeadlineExceeded = fmt.Errorf("%w: progress deadline exceeded", ErrRolloutFailed)
+	// ErrRolloutSuperseded indicates a newer rollout replaced the awaited one (see RolloutMatch)
+	ErrRolloutSuperseded = fmt.Errorf("%w: superseded by a newer rollout", ErrRolloutFailed)
+	// ErrRolloutPaused indicates a paused deployment ended --until-complete (see HaltPolicy)
+	ErrRolloutPaused = errors.New("deployment paused")
+	// ErrReplicaFailure indicates a deployment unable to create pods ended --until-complete (see HaltPolicy)
+	ErrReplicaFailure = errors.New("replica failure")
+	// ErrRolloutHalted indicates --until-complete stopped on a halted rollout with HaltExit
+	ErrRolloutHalted = errors.New("rollout halted")
+	// ErrRolledBack indicates rollout failed and was successfully rolled back to the previous revision
+	ErrRolledBack = errors.New("rollout failed and was rolled back")
+	// ErrWatchTimeout indicates rollouts were not done within the --timeout watch budget
+	ErrWatchTimeout = errors.New("watch timeout")
+	// ErrInvalidArguments indicates invalid command-line arguments or flag combinations
+	ErrInvalidArguments = errors.New("invalid arguments")
+	// ErrUnsupportedStrategy indicates a workload whose update strategy has no rollout to watch (OnDelete)
+	ErrUnsupportedStrategy = fmt.Errorf("%w: rollout status is only available for RollingUpdate strategy type",
+		ErrInvalidArguments)
+	// ErrWorkloadNotFound indicates a monitored workload does not exist
+	ErrWorkloadNotFound = errors.New("workload not found")
+	// ErrKubeconfig indicates the kubeconfig could not be loaded or is invalid
+	ErrKubeconfig = errors.New("kubeconfig error")
+	// ErrAccessDenied indicates the API server rejected the credentials or RBAC denied access
+	ErrAccessDenied = errors.New("access denied")
+	// ErrClusterUnavailable indicates the API server could not be reached or failed (worth retrying)
+	ErrClusterUnavailable = errors.New("cluster unavailable")
+	// ErrCancelled indicates monitoring was interrupted by the user (Ctrl+C)
+	ErrCancelled = errors.New("monitoring cancelled")
+)
+
+// classifiedError tags an error with its class while keeping the original message.
+type classifiedError struct {
+	class error
+	err   error
+}
+
+// Error returns the original message.
+func (e *classifiedError) Error() string { return e.err.Error() }
+
+// Unwrap exposes both the class and the original error to errors.Is and errors.As.
+func (e *classifiedError) Unwrap() []error { return []error{e.class, e.err} }
+
+// Classify tags err with class, so errors.Is(err, class) holds without changing the message.
+// Returns nil if err is nil.
+func Classify(class, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &classifiedError{class: class, err: err}
+}
+
+// classifyAPIError tags a Kubernetes API error with its class: ErrWorkloadNotFound for missing
+// objects, ErrAccessDenied for authentication and RBAC failures, ErrClusterUnavailable otherwise.
+func classifyAPIError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case apierrors.IsNotFound(err):
+		return Classify(ErrWorkloadNotFound, err)
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return Classify(ErrAccessDenied, err)
+	}
+
+	return Classify(ErrClusterUnavailable, err)
+}
+
+// isPersistentAPIError reports whether retrying a failed API request cannot succeed
+// without user action, as for rejected credentials or RBAC denials.
+func isPersistentAPIError(err error) bool {
+	return apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err)
+}
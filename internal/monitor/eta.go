@@ -0,0 +1,181 @@
+package monitor
+
+// This file contains the rollout completion estimators (--eta-model).
+
+import (
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// etaSmoothing is the weight of the latest availability gain in the EWMA pace.
+// Surge-batched rollouts gain pods in bursts, so older batches keep a large share.
+const etaSmoothing = 0.3
+
+// ETAModel selects how the completion time of a rollout is estimated.
+type ETAModel string
+
+const (
+	// ETALinear extrapolates the average pace since the rollout started (default)
+	ETALinear ETAModel = "linear"
+	// ETAEWMA extrapolates an exponentially weighted moving average of recent availability gains
+	ETAEWMA ETAModel = "ewma"
+	// ETAHistory starts from the typical duration of previous rollouts (see History) and shifts
+	// to the observed pace as pods become available; linear without history
+	ETAHistory ETAModel = "history"
+)
+
+// ETAModels lists all supported ETA models.
+var ETAModels = []ETAModel{ETALinear, ETAEWMA, ETAHistory}
+
+// etaProgress is the state of a rollout an estimate is made from.
+type etaProgress struct {
+	available int32 // Available replicas of the new revision
+	target    int32 // Replicas the rollout updates
+	elapsed   time.Duration
+	history   types.RolloutHistory
+}
+
+// ratio returns the share of the target already available (0-1).
+func (p etaProgress) ratio() float64 {
+	return calculateProgress(p.available, p.target)
+}
+
+// etaEstimator predicts how long a rollout still takes. Each rollout gets a new estimator,
+// fed every time its available count changes, including the first snapshot.
+type etaEstimator interface {
+	// remaining returns the estimated time to completion, false without an estimate.
+	remaining(p etaProgress) (time.Duration, bool)
+}
+
+// newETAEstimator creates an estimator for a new rollout.
+func newETAEstimator(model ETAModel) etaEstimator {
+	switch model {
+	case ETAEWMA:
+		return &ewmaEstimator{}
+	case ETAHistory:
+		return historyEstimator{}
+	case ETALinear:
+	}
+
+	return linearEstimator{}
+}
+
+// linearEstimator assumes the rest of the rollout keeps the average pace since it started.
+type linearEstimator struct{}
+
+func (linearEstimator) remaining(p etaProgress) (time.Duration, bool) {
+	progress := p.ratio()
+	if progress < MinProgressForETA || p.elapsed <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(p.elapsed) * (1 - progress) / progress), true
+}
+
+// ewmaEstimator extrapolates the recent pace: an exponentially weighted moving average of
+// replicas gained per second, sampled whenever the available count grows.
+type ewmaEstimator struct {
+	available int32         // Available count of the previous sample
+	elapsed   time.Duration // Elapsed time of the previous sample
+	pace      float64       // Replicas per second, 0 until the first gain
+}
+
+func (e *ewmaEstimator) remaining(p etaProgress) (time.Duration, bool) {
+	gained, interval := p.available-e.available, p.elapsed-e.elapsed
+
+	if gained > 0 && interval > 0 {
+		sample := float64(gained) / interval.Seconds()
+		if e.pace == 0 {
+			e.pace = sample
+		} else {
+			e.pace = etaSmoothing*sample + (1-etaSmoothing)*e.pace
+		}
+	}
+
+	// Pods lost to restarts or evictions restart the interval, the pace is kept
+	e.available, e.elapsed = p.available, p.elapsed
+
+	if p.ratio() < MinProgressForETA || e.pace <= 0 {
+		return 0, false
+	}
+
+	return time.Duration(float64(p.target-p.available) / e.pace * float64(time.Second)), true
+}
+
+// historyEstimator blends the typical duration of previous rollouts with the linear estimate,
+// weighting the linear one by progress. Before any pod is available it counts down to the typical
+// duration, so there is an estimate from the very first snapshot. A rollout slower than typical
+// is never predicted to finish sooner than its own pace says.
+type historyEstimator struct{}
+
+func (historyEstimator) remaining(p etaProgress) (time.Duration, bool) {
+	if p.history.Samples == 0 {
+		return linearEstimator{}.remaining(p)
+	}
+
+	progress := p.ratio()
+	typical := time.Duration((1 - progress) * float64(max(0, p.history.P50-p.elapsed)))
+
+	// Weighted by progress, the linear estimate elapsed*(1-progress)/progress reduces to elapsed*(1-progress)
+	var observed time.Duration
+	if progress > 0 {
+		observed = time.Duration((1 - progress) * float64(p.elapsed))
+	}
+
+	remaining := typical + observed
+
+	// Past the typical duration history no longer holds the estimate back from the observed pace
+	if p.elapsed >= p.history.P50 {
+		if linear, ok := (linearEstimator{}).remaining(p); ok {
+			remaining = max(remaining, linear)
+		}
+	}
+
+	return remaining, remaining > 0
+}
+
+// updateETA calculates ETA with smooth countdown behavior.
+// Only recalculates when Available count changes; otherwise returns existing target.
+// This ensures ETA counts down smoothly between progress updates.
+func (t *rolloutTracker) updateETA(s *types.RolloutSnapshot, model ETAModel) *time.Time {
+	// Reset state if ReplicaSet changed (new rollout started)
+	if s.NewRSName != t.etaLastRSName || t.eta == nil {
+		t.etaLastRSName = s.NewRSName
+		t.etaLastAvail = -1 // Estimate on the first snapshot, before any pod is available
+		t.etaTarget = nil
+		t.eta = newETAEstimator(model)
+	}
+
+	progress := etaProgress{
+		available: s.NewRS.Available,
+		target:    s.UpdateTarget(),
+		elapsed:   s.SnapshotTime.Sub(s.StartTime),
+		history:   s.History,
+	}
+
+	if progress.target == 0 || progress.ratio() >= 1.0 {
+		t.etaTarget = nil
+
+		return nil
+	}
+
+	// Only recalculate when available count actually changes
+	if progress.available != t.etaLastAvail {
+		t.etaLastAvail = progress.available
+		t.etaTarget = nil
+
+		remaining, ok := t.eta.remaining(progress)
+		if ok && remaining < MaxRealisticETAHours*time.Hour {
+			eta := s.SnapshotTime.Add(remaining)
+			t.etaTarget = &eta
+		}
+	}
+
+	// Return existing target - it counts down naturally
+	if t.etaTarget != nil && !t.etaTarget.After(s.SnapshotTime) {
+		return nil // Don't show negative/zero ETA
+	}
+
+	return t.etaTarget
+}
@@ -0,0 +1,80 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// TestHistoryEstimator checks the history-seeded estimate before and after the typical duration passes.
+func TestHistoryEstimator(t *testing.T) {
+	history := types.RolloutHistory{Samples: 5, P50: 4 * time.Minute, P90: 6 * time.Minute}
+
+	tests := []struct {
+		name      string
+		available int32
+		elapsed   time.Duration
+		history   types.RolloutHistory
+		want      time.Duration
+		wantOK    bool
+	}{
+		{
+			name:    "counts down to the typical duration before pods are available",
+			elapsed: time.Minute, history: history,
+			want: 3 * time.Minute, wantOK: true,
+		},
+		{
+			name:      "blends history with the observed pace",
+			available: 5, elapsed: 2 * time.Minute, history: history,
+			want: time.Minute + time.Minute, wantOK: true, // Half of the typical 2m left plus half of 2m elapsed
+		},
+		{
+			name:      "follows the observed pace once past the typical duration",
+			available: 2, elapsed: 8 * time.Minute, history: history,
+			want: 32 * time.Minute, wantOK: true, // 8m for 2 of 10 replicas, linear 8m*0.8/0.2
+		},
+		{
+			name:    "no estimate past the typical duration without available pods",
+			elapsed: 8 * time.Minute, history: history,
+		},
+		{
+			name:      "linear without history",
+			available: 5, elapsed: 2 * time.Minute,
+			want: 2 * time.Minute, wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := historyEstimator{}.remaining(etaProgress{
+				available: tt.available,
+				target:    10,
+				elapsed:   tt.elapsed,
+				history:   tt.history,
+			})
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("remaining() = %s, %v, want %s, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// TestHistoryEstimatorNotBelowLinear checks that a rollout running past its typical duration
+// is never estimated to finish sooner than the linear model predicts.
+func TestHistoryEstimatorNotBelowLinear(t *testing.T) {
+	history := types.RolloutHistory{Samples: 5, P50: 4 * time.Minute, P90: 6 * time.Minute}
+
+	for elapsed := history.P50; elapsed <= 4*history.P50; elapsed += 30 * time.Second {
+		for available := int32(1); available < 10; available++ {
+			p := etaProgress{available: available, target: 10, elapsed: elapsed, history: history}
+
+			got, _ := historyEstimator{}.remaining(p)
+			linear, _ := linearEstimator{}.remaining(p)
+
+			if got < linear {
+				t.Fatalf("%d/10 available after %s: remaining() = %s, below linear %s", available, elapsed, got, linear)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ package monitor
 
 import (
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -13,10 +14,15 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
-// eventData holds a single event's message and timestamp for clustering.
+// maxEventExemplars limits the distinct messages kept per event cluster.
+const maxEventExemplars = 10
+
+// eventData holds a single event's message, timestamps and involved object for clustering.
 type eventData struct {
 	message string
 	time    time.Time
+	first   time.Time // First occurrence of a repeated event
+	object  string    // Name of the object the event is about
 }
 
 // SummarizeEvents processes K8s events into clustered summary.
@@ -25,8 +31,8 @@ func SummarizeEvents(events []corev1.Event, ignoreRegex *regexp.Regexp, threshol
 		return types.EventSummary{}
 	}
 
-	// Group by Type+Reason, apply ignore filter
-	type typeReasonKey struct{ Type, Reason string }
+	// Group by Source+Type+Reason, apply ignore filter
+	type typeReasonKey struct{ Source, Type, Reason string }
 
 	groups := make(map[typeReasonKey][]eventData)
 	ignoredCount := 0
@@ -41,10 +47,12 @@ func SummarizeEvents(events []corev1.Event, ignoreRegex *regexp.Regexp, threshol
 			}
 		}
 
-		key := typeReasonKey{Type: event.Type, Reason: event.Reason}
+		key := typeReasonKey{Source: eventSource(&event), Type: event.Type, Reason: event.Reason}
 		groups[key] = append(groups[key], eventData{
 			message: event.Message,
 			time:    getEventTime(&event),
+			first:   getEventFirstTime(&event),
+			object:  event.InvolvedObject.Name,
 		})
 	}
 
@@ -56,11 +64,12 @@ func SummarizeEvents(events []corev1.Event, ignoreRegex *regexp.Regexp, threshol
 	var result []types.EventCluster
 
 	for key, group := range groups {
-		clusters := clusterWithDrain(group, threshold, key.Type, key.Reason)
+		clusters := clusterWithDrain(group, threshold, key.Source, key.Type, key.Reason)
 		result = append(result, clusters...)
 	}
 
-	// Sort: warnings first, then by count, then by reason
+	// Sort: warnings first, then by count, then by reason, source and message.
+	// Groups come from map iteration, so the order must be total to keep equal summaries equal.
 	sort.Slice(result, func(i, j int) bool {
 		if result[i].Type != result[j].Type {
 			return result[i].Type == corev1.EventTypeWarning
@@ -70,7 +79,15 @@ func SummarizeEvents(events []corev1.Event, ignoreRegex *regexp.Regexp, threshol
 			return result[i].ExemplarCount > result[j].ExemplarCount
 		}
 
-		return result[i].Reason < result[j].Reason
+		if result[i].Reason != result[j].Reason {
+			return result[i].Reason < result[j].Reason
+		}
+
+		if result[i].Source != result[j].Source {
+			return result[i].Source < result[j].Source
+		}
+
+		return result[i].Message < result[j].Message
 	})
 
 	return types.EventSummary{
@@ -80,62 +97,105 @@ func SummarizeEvents(events []corev1.Event, ignoreRegex *regexp.Regexp, threshol
 }
 
 // clusterWithDrain uses Drain algorithm to cluster similar messages.
-func clusterWithDrain(events []eventData, threshold float64, eventType, reason string) []types.EventCluster {
+func clusterWithDrain(events []eventData, threshold float64, source, eventType, reason string) []types.EventCluster {
 	if len(events) == 0 {
 		return nil
 	}
 
-	// Configure Drain
-	config := drain.DefaultConfig()
-	config.SimTh = threshold
-	d := drain.New(config)
-
-	// Phase 1: Train all messages first (templates evolve as Drain learns)
-	type trainedEvent struct {
-		cluster *drain.LogCluster
-		time    time.Time
-	}
-
-	trained := make([]trainedEvent, len(events))
-
+	messages := make([]string, len(events))
 	for i, evt := range events {
-		trained[i] = trainedEvent{
-			cluster: d.Train(sanitizeMessage(evt.message)),
-			time:    evt.time,
-		}
+		messages[i] = evt.message
 	}
 
+	// Phase 1: Train all messages first (templates evolve as Drain learns)
+	assigned := trainDrain(messages, threshold)
+
 	// Phase 2: Group by final cluster (templates may have evolved during training)
-	clusterTimes := make(map[*drain.LogCluster][]time.Time)
+	clusterEvents := make(map[*drain.LogCluster][]eventData)
 
-	for _, te := range trained {
-		clusterTimes[te.cluster] = append(clusterTimes[te.cluster], te.time)
+	for i, cluster := range assigned {
+		clusterEvents[cluster] = append(clusterEvents[cluster], events[i])
 	}
 
 	// Phase 3: Build EventClusters with final templates
-	result := make([]types.EventCluster, 0, len(clusterTimes))
-
-	for cluster, times := range clusterTimes {
-		// Find latest timestamp
-		var lastSeen time.Time
-		for _, t := range times {
-			if t.After(lastSeen) {
-				lastSeen = t
-			}
-		}
+	result := make([]types.EventCluster, 0, len(clusterEvents))
 
-		result = append(result, types.EventCluster{
+	for cluster, members := range clusterEvents {
+		c := types.EventCluster{
+			Source:        source,
 			Type:          eventType,
 			Reason:        reason,
 			Message:       extractTemplate(cluster.String()),
-			ExemplarCount: len(times),
-			LastSeen:      lastSeen,
-		})
+			ExemplarCount: len(members),
+			Exemplars:     eventExemplars(members),
+		}
+
+		for _, evt := range members {
+			if evt.time.After(c.LastSeen) {
+				c.LastSeen = evt.time
+			}
+
+			if c.FirstSeen.IsZero() || evt.first.Before(c.FirstSeen) {
+				c.FirstSeen = evt.first
+			}
+
+			if source == types.PodEventSource && !slices.Contains(c.Pods, evt.object) {
+				c.Pods = append(c.Pods, evt.object)
+			}
+		}
+
+		slices.Sort(c.Pods)
+		result = append(result, c)
 	}
 
 	return result
 }
 
+// eventExemplars returns the distinct messages of clustered events, most recent first.
+func eventExemplars(events []eventData) []types.EventExemplar {
+	var exemplars []types.EventExemplar
+
+	for _, evt := range events {
+		message := sanitizeMessage(evt.message)
+
+		i := slices.IndexFunc(exemplars, func(e types.EventExemplar) bool { return e.Message == message })
+		if i < 0 {
+			exemplars = append(exemplars, types.EventExemplar{Message: message})
+			i = len(exemplars) - 1
+		}
+
+		exemplars[i].Count++
+		if evt.time.After(exemplars[i].LastSeen) {
+			exemplars[i].LastSeen = evt.time
+		}
+	}
+
+	sort.Slice(exemplars, func(i, j int) bool {
+		if !exemplars[i].LastSeen.Equal(exemplars[j].LastSeen) {
+			return exemplars[i].LastSeen.After(exemplars[j].LastSeen)
+		}
+
+		return exemplars[i].Message < exemplars[j].Message
+	})
+
+	return exemplars[:min(len(exemplars), maxEventExemplars)]
+}
+
+// trainDrain clusters messages with the Drain algorithm and returns the cluster of each message.
+// Callers must read templates only after training, once they have stopped evolving.
+func trainDrain(messages []string, threshold float64) []*drain.LogCluster {
+	config := drain.DefaultConfig()
+	config.SimTh = threshold
+	d := drain.New(config)
+
+	trained := make([]*drain.LogCluster, len(messages))
+	for i, msg := range messages {
+		trained[i] = d.Train(sanitizeMessage(msg))
+	}
+
+	return trained
+}
+
 // extractTemplate extracts template from Drain's String() format.
 // Input:  "id={1} : size={3} : template content here"
 // Output: "template content here"
@@ -150,6 +210,44 @@ func extractTemplate(s string) string {
 	return s[idx+len(sep):]
 }
 
+// eventSource labels the object an event is about, kubectl style ("replicaset/web-5d8f").
+// Pod events share a single label so they keep clustering across pods.
+func eventSource(evt *corev1.Event) string {
+	if evt.InvolvedObject.Kind == "Pod" {
+		return types.PodEventSource
+	}
+
+	return strings.ToLower(evt.InvolvedObject.Kind) + "/" + evt.InvolvedObject.Name
+}
+
+// topEventClusters returns up to limit event clusters, warnings first, keeping their order otherwise.
+func topEventClusters(clusters []types.EventCluster, limit int) []types.EventCluster {
+	var top []types.EventCluster
+
+	for _, warnings := range []bool{true, false} {
+		for _, c := range clusters {
+			if (c.Type == corev1.EventTypeWarning) == warnings && len(top) < limit {
+				top = append(top, c)
+			}
+		}
+	}
+
+	return top
+}
+
+// eventsSince drops events last seen before since.
+func eventsSince(events []corev1.Event, since time.Time) []corev1.Event {
+	var result []corev1.Event
+
+	for _, event := range events {
+		if !getEventTime(&event).Before(since) {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
 // getEventTime returns the best timestamp for an event.
 func getEventTime(evt *corev1.Event) time.Time {
 	if !evt.LastTimestamp.IsZero() {
@@ -163,6 +261,15 @@ func getEventTime(evt *corev1.Event) time.Time {
 	return evt.CreationTimestamp.Time
 }
 
+// getEventFirstTime returns when a possibly repeated event first occurred.
+func getEventFirstTime(evt *corev1.Event) time.Time {
+	if !evt.FirstTimestamp.IsZero() {
+		return evt.FirstTimestamp.Time
+	}
+
+	return getEventTime(evt)
+}
+
 // sanitizeMessage normalizes whitespace.
 func sanitizeMessage(msg string) string {
 	msg = strings.ReplaceAll(msg, "\n", " ")
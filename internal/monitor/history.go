@@ -0,0 +1,402 @@
+package monitor
+
+// This file contains the rollout history file: every rollout observed to finish is appended to it,
+// so the duration of a rollout can be compared with previous rollouts of the same workload.
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+const (
+	// DefaultHistoryWindow is how many recent completed rollouts typical durations are computed from
+	DefaultHistoryWindow = 20
+	// MaxHistoryRollouts is how many recent rollouts of each workload the history file keeps
+	MaxHistoryRollouts = 100
+	// maxHistoryEvents limits the event clusters kept per rollout, warnings first
+	maxHistoryEvents = 3
+	// historyFileMode keeps the history private, it names workloads of every watched cluster
+	historyFileMode = 0o600
+	// historyDirMode is used when the history directory does not exist yet
+	historyDirMode = 0o700
+)
+
+// History outcomes, the same values as the JSON Lines "status" field.
+const (
+	HistoryComplete         = "complete"
+	HistoryDeadlineExceeded = "deadline_exceeded"
+)
+
+// HistoryRecord is one finished rollout in the history file.
+// Field names are part of the history file format - do not rename.
+type HistoryRecord struct {
+	Cluster    string         `json:"cluster"` // API server the workload runs on
+	Namespace  string         `json:"namespace"`
+	Kind       string         `json:"kind"` // Lowercase workload kind, e.g. "deployment"
+	Name       string         `json:"name"`
+	Revision   int64          `json:"revision,omitempty"` // Deployment revision or ControllerRevision number
+	ReplicaSet string         `json:"replicaSet"`         // New ReplicaSet or update ControllerRevision
+	Started    time.Time      `json:"started"`
+	Ended      time.Time      `json:"ended"`
+	Outcome    string         `json:"outcome"`            // HistoryComplete or HistoryDeadlineExceeded
+	Rollback   bool           `json:"rollback,omitempty"` // Rollback of a failed rollout (--rollback-on-failure)
+	Events     []HistoryEvent `json:"events,omitempty"`   // Top event clusters, warnings first
+}
+
+// HistoryEvent is an event cluster of a recorded rollout.
+type HistoryEvent struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// Duration returns how long the rollout took.
+func (r HistoryRecord) Duration() time.Duration {
+	return r.Ended.Sub(r.Started)
+}
+
+// History is the rollout history file of one cluster and namespace.
+// The file is shared by every watcher of the user: records are appended, one JSON object per line,
+// and the file is rewritten only to trim it (see OpenHistory).
+type History struct {
+	path      string
+	cluster   string
+	namespace string
+
+	mu       sync.Mutex
+	records  []HistoryRecord // Records of this cluster and namespace, oldest first
+	writeErr error           // First failure to append a record
+}
+
+// DefaultHistoryPath returns the history file in the user's configuration directory,
+// e.g. ~/.config/kubectl-watch-rollout/history.jsonl on Linux.
+func DefaultHistoryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate rollout history: %w", err)
+	}
+
+	return filepath.Join(dir, "kubectl-watch-rollout", "history.jsonl"), nil
+}
+
+// OpenHistory loads the records of a cluster and namespace from the history file.
+// A missing file is an empty history. Lines that cannot be decoded, e.g. cut short by a full disk, are skipped.
+// Only the last MaxHistoryRollouts rollouts of each workload are loaded. Once the file holds as many
+// older records or undecodable lines, it is trimmed to the kept ones, so it stays bounded; a failure
+// to trim is reported by Err.
+func OpenHistory(path, cluster, namespace string) (*History, error) {
+	h := &History{path: path, cluster: cluster, namespace: namespace}
+
+	lines, err := readHistoryLines(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return h, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	kept := keepRecentRollouts(lines)
+
+	for _, line := range kept {
+		if line.record.Cluster == cluster && line.record.Namespace == namespace && !h.has(line.record) {
+			h.records = append(h.records, line.record)
+		}
+	}
+
+	if len(lines)-len(kept) >= MaxHistoryRollouts {
+		err = h.rewrite(kept)
+		if err != nil {
+			h.writeErr = fmt.Errorf("failed to trim rollout history: %w", err)
+		}
+	}
+
+	return h, nil
+}
+
+// historyLine is a line of the history file, kept as read so trimming preserves it exactly.
+type historyLine struct {
+	raw    []byte
+	record HistoryRecord
+	valid  bool // The line decodes as a record
+}
+
+// readHistoryLines reads every line of the history file.
+func readHistoryLines(path string) ([]historyLine, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rollout history: %w", err)
+	}
+	defer file.Close() //nolint:errcheck // read-only file, close errors not actionable
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1<<20) //nolint:mnd // records are small, a megabyte leaves ample room
+
+	var lines []historyLine
+
+	for scanner.Scan() {
+		line := historyLine{raw: slices.Clone(scanner.Bytes())}
+		line.valid = json.Unmarshal(line.raw, &line.record) == nil
+		lines = append(lines, line)
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rollout history: %w", err)
+	}
+
+	return lines, nil
+}
+
+// keepRecentRollouts returns the records among lines that are among the last MaxHistoryRollouts
+// of their workload, in file order.
+func keepRecentRollouts(lines []historyLine) []historyLine {
+	type workload struct{ cluster, namespace, kind, name string }
+
+	newer := make(map[workload]int) // Records of the workload after the current line
+	keep := make([]bool, len(lines))
+
+	for i, line := range slices.Backward(lines) {
+		if !line.valid {
+			continue
+		}
+
+		key := workload{line.record.Cluster, line.record.Namespace, line.record.Kind, line.record.Name}
+		keep[i] = newer[key] < MaxHistoryRollouts
+		newer[key]++
+	}
+
+	var kept []historyLine
+
+	for i, line := range lines {
+		if keep[i] {
+			kept = append(kept, line)
+		}
+	}
+
+	return kept
+}
+
+// rewrite replaces the history file with lines. The new file is renamed into place, so concurrent
+// readers see either version; a record another watcher appends meanwhile is lost.
+func (h *History) rewrite(lines []historyLine) error {
+	file, err := os.CreateTemp(filepath.Dir(h.path), ".history-*.jsonl")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name()) //nolint:errcheck // fails harmlessly once renamed
+
+	var data []byte
+	for _, line := range lines {
+		data = append(append(data, line.raw...), '\n')
+	}
+
+	_, err = file.Write(data)
+
+	err = errors.Join(err, file.Chmod(historyFileMode), file.Close())
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), h.path)
+}
+
+// Rollouts returns the recorded rollouts of a workload, oldest first.
+func (h *History) Rollouts(kind types.WorkloadKind, name string) []HistoryRecord {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var records []HistoryRecord
+
+	for _, r := range h.records {
+		if r.Kind == strings.ToLower(string(kind)) && r.Name == name {
+			records = append(records, r)
+		}
+	}
+
+	return records
+}
+
+// Err returns the first error appending a record. Failing to record history never fails a watch.
+func (h *History) Err() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.writeErr
+}
+
+// has reports whether a rollout is already recorded, e.g. by another watcher of the same workload.
+func (h *History) has(record HistoryRecord) bool {
+	return slices.ContainsFunc(h.records, func(r HistoryRecord) bool {
+		return r.Kind == record.Kind && r.Name == record.Name && r.ReplicaSet == record.ReplicaSet &&
+			r.Ended.Equal(record.Ended)
+	})
+}
+
+// add appends a finished rollout to the history file, unless it is already recorded.
+func (h *History) add(record HistoryRecord) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.has(record) {
+		return
+	}
+
+	h.records = append(h.records, record)
+
+	err := h.append(record)
+	if err != nil && h.writeErr == nil {
+		h.writeErr = fmt.Errorf("failed to record rollout history: %w", err)
+	}
+}
+
+// append writes a record as one line, so concurrent watchers never interleave partial records.
+func (h *History) append(record HistoryRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(h.path), historyDirMode)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, historyFileMode)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+
+	return errors.Join(err, file.Close())
+}
+
+// SummarizeHistory computes typical durations from the last DefaultHistoryWindow completed rollouts.
+// Failed rollouts and rollbacks are left out, their durations say nothing about a healthy rollout.
+func SummarizeHistory(records []HistoryRecord) types.RolloutHistory {
+	var durations []time.Duration
+
+	for _, r := range slices.Backward(records) {
+		if len(durations) == DefaultHistoryWindow {
+			break
+		}
+
+		if r.Outcome == HistoryComplete && !r.Rollback {
+			durations = append(durations, r.Duration())
+		}
+	}
+
+	if len(durations) == 0 {
+		return types.RolloutHistory{}
+	}
+
+	slices.Sort(durations)
+
+	return types.RolloutHistory{
+		Samples: len(durations),
+		P50:     percentile(durations, 50), //nolint:mnd // median
+		P90:     percentile(durations, 90), //nolint:mnd // 90th percentile
+	}
+}
+
+// percentile returns the nearest-rank percentile p (0-100) of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 //nolint:mnd // ceil(p/100 * n)
+
+	return sorted[max(rank, 1)-1]
+}
+
+// summary returns typical durations of a workload's recorded rollouts.
+// A rollout in progress is only recorded once it finishes, so it never skews its own comparison.
+func (h *History) summary(s *types.RolloutSnapshot) types.RolloutHistory {
+	return SummarizeHistory(h.Rollouts(s.Kind, s.WorkloadName))
+}
+
+// historyState follows the rollouts of a workload until they finish and can be recorded.
+// Only rollouts seen in progress are recorded: attaching to a finished rollout tells nothing about its duration.
+type historyState struct {
+	rollout  string    // NewRSName of the last snapshot
+	lastSeen time.Time // SnapshotTime of the last snapshot
+	pending  string    // Rollout seen in progress and not recorded yet
+	started  time.Time // When the pending rollout started
+}
+
+// finished reports whether a snapshot finishes the pending rollout, returning when it started.
+func (h *historyState) finished(s *types.RolloutSnapshot) (time.Time, bool) {
+	changed := h.rollout != "" && s.NewRSName != h.rollout
+	previous := h.lastSeen
+	h.rollout, h.lastSeen = s.NewRSName, s.SnapshotTime
+
+	if !s.Status.IsDone() && s.NewRSName != h.pending && (changed || s.OldRS.Current > 0) {
+		h.pending, h.started = s.NewRSName, s.StartTime
+
+		// A ReplicaSet revived by a rollback was created long before, the rollout started after the last snapshot
+		if changed && s.StartTime.Before(previous) {
+			h.started = previous
+		}
+	}
+
+	if h.pending != s.NewRSName || (s.Status != types.StatusComplete && s.Status != types.StatusDeadlineExceeded) {
+		return time.Time{}, false
+	}
+
+	h.pending = ""
+
+	return h.started, true
+}
+
+// recordHistory appends a rollout to the history once it finishes and attaches typical durations to the snapshot.
+func (c *Controller) recordHistory(t *rolloutTracker, s *types.RolloutSnapshot) {
+	if started, ok := t.history.finished(s); ok {
+		c.config.History.add(newHistoryRecord(c.config.History, s, started))
+	}
+
+	s.History = c.config.History.summary(s)
+}
+
+// newHistoryRecord describes a finished rollout for the history file.
+func newHistoryRecord(h *History, s *types.RolloutSnapshot, started time.Time) HistoryRecord {
+	ended := s.SnapshotTime
+	if s.ProgressUpdateTime != nil && s.ProgressUpdateTime.After(started) {
+		ended = *s.ProgressUpdateTime
+	}
+
+	record := HistoryRecord{
+		Cluster:    h.cluster,
+		Namespace:  h.namespace,
+		Kind:       strings.ToLower(string(s.Kind)),
+		Name:       s.WorkloadName,
+		Revision:   s.Revision,
+		ReplicaSet: s.NewRSName,
+		Started:    started,
+		Ended:      ended,
+		Outcome:    jsonStatus(s.Status),
+		Rollback:   s.RollbackRevision > 0,
+	}
+
+	for _, c := range topEventClusters(s.Events.Clusters, maxHistoryEvents) {
+		record.Events = append(record.Events, HistoryEvent{
+			Type: c.Type, Reason: c.Reason, Message: c.Message, Count: c.ExemplarCount,
+		})
+	}
+
+	return record
+}
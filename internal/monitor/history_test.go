@@ -0,0 +1,109 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// TestOpenHistoryTrims checks that only the recent rollouts of each workload are loaded,
+// and that the file is rewritten once enough older records accumulate.
+func TestOpenHistoryTrims(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// history writes rollouts of web and a few of api, with an undecodable line in between
+	history := func(t *testing.T, webRollouts int) (string, []byte) {
+		t.Helper()
+
+		var data bytes.Buffer
+
+		write := func(name string, i int) {
+			line, err := json.Marshal(HistoryRecord{
+				Cluster: "https://cluster", Namespace: "default", Kind: "deployment", Name: name,
+				ReplicaSet: fmt.Sprintf("%s-%d", name, i), Started: start.Add(time.Duration(i) * time.Hour),
+				Ended: start.Add(time.Duration(i)*time.Hour + time.Minute), Outcome: HistoryComplete,
+			})
+			if err != nil {
+				t.Fatalf("failed to encode record: %v", err)
+			}
+
+			data.Write(append(line, '\n'))
+		}
+
+		for i := range 3 {
+			write("api", i)
+		}
+
+		data.WriteString("{\"cluster\":\n")
+
+		for i := range webRollouts {
+			write("web", i)
+		}
+
+		path := filepath.Join(t.TempDir(), "history.jsonl")
+
+		err := os.WriteFile(path, data.Bytes(), historyFileMode)
+		if err != nil {
+			t.Fatalf("failed to write history: %v", err)
+		}
+
+		return path, data.Bytes()
+	}
+
+	tests := []struct {
+		name        string
+		webRollouts int
+		wantLines   int // Lines left in the file, 0 when it must be left untouched
+	}{
+		{name: "below the limit", webRollouts: 50},
+		{name: "few older records", webRollouts: MaxHistoryRollouts + 20},
+		{name: "many older records", webRollouts: 2*MaxHistoryRollouts + 10, wantLines: MaxHistoryRollouts + 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, original := history(t, tt.webRollouts)
+
+			h, err := OpenHistory(path, "https://cluster", "default")
+			if err != nil {
+				t.Fatalf("OpenHistory() error = %v", err)
+			}
+
+			if h.Err() != nil {
+				t.Fatalf("Err() = %v", h.Err())
+			}
+
+			web := h.Rollouts(types.KindDeployment, "web")
+			if want := min(tt.webRollouts, MaxHistoryRollouts); len(web) != want {
+				t.Fatalf("loaded %d rollouts of web, want %d", len(web), want)
+			}
+
+			if last := fmt.Sprintf("web-%d", tt.webRollouts-1); web[len(web)-1].ReplicaSet != last {
+				t.Errorf("last rollout of web = %s, want %s", web[len(web)-1].ReplicaSet, last)
+			}
+
+			if got := len(h.Rollouts(types.KindDeployment, "api")); got != 3 {
+				t.Errorf("loaded %d rollouts of api, want 3", got)
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read history: %v", err)
+			}
+
+			if tt.wantLines == 0 && !bytes.Equal(data, original) {
+				t.Errorf("history file was rewritten")
+			}
+
+			if lines := bytes.Count(data, []byte("\n")); tt.wantLines != 0 && lines != tt.wantLines {
+				t.Errorf("history file has %d lines, want %d", lines, tt.wantLines)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package monitor
+
+// This file contains the JSONView implementation for machine-readable JSON Lines output.
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// JSONSchemaVersion identifies the JSON Lines record layout.
+// Adding fields keeps the version; renaming, removing or changing the meaning of a field bumps it.
+const JSONSchemaVersion = 1
+
+// jsonRecord is one JSON Lines record describing a rollout snapshot.
+// Field names are part of the public output contract - do not rename.
+type jsonRecord struct {
+	SchemaVersion    int          `json:"schemaVersion"`
+	Time             time.Time    `json:"time"`
+	Kind             string       `json:"kind"`
+	Name             string       `json:"name"`
+	Revision         string       `json:"revision"` // New ReplicaSet or update ControllerRevision
+	Status           string       `json:"status"`
+	StatusMessage    string       `json:"statusMessage,omitempty"`    // Explains the status, e.g. why a rollout is halted
+	Waiting          bool         `json:"waiting,omitempty"`          // No rollout data yet, statusMessage says why
+	RollbackRevision int64        `json:"rollbackRevision,omitempty"` // Revision a failed rollout was rolled back to
+	Strategy         jsonStrategy `json:"strategy"`
+	Replicas         jsonReplicas `json:"replicas"`
+	Progress         jsonProgress `json:"progress"`
+	StartTime        time.Time    `json:"startTime"`
+	// DurationSeconds is the rollout duration when done, otherwise time elapsed so far
+	DurationSeconds float64       `json:"durationSeconds"`
+	ETA             *time.Time    `json:"eta,omitempty"`
+	ETASeconds      *float64      `json:"etaSeconds,omitempty"`
+	Events          jsonEvents    `json:"events"`
+	Problems        []jsonProblem `json:"problems"`
+	Logs            jsonLogs      `json:"logs"`
+}
+
+type jsonStrategy struct {
+	Type           string `json:"type"`
+	MaxSurge       string `json:"maxSurge,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+	Partition      *int32 `json:"partition,omitempty"`
+}
+
+type jsonReplicas struct {
+	Desired int32            `json:"desired"`
+	Target  int32            `json:"target"` // Replicas expected to be updated (desired minus partition)
+	New     jsonReplicaState `json:"new"`
+	Old     jsonReplicaState `json:"old"`
+}
+
+type jsonReplicaState struct {
+	Current   int32 `json:"current"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+}
+
+type jsonProgress struct {
+	New float64 `json:"new"`
+	Old float64 `json:"old"`
+}
+
+type jsonEvents struct {
+	Clusters []jsonEventCluster `json:"clusters"`
+	Ignored  int                `json:"ignored"`
+}
+
+type jsonEventCluster struct {
+	Source   string    `json:"source"`
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type jsonProblem struct {
+	Container   string           `json:"container"`
+	Reason      string           `json:"reason"`
+	Message     string           `json:"message,omitempty"`
+	Pods        []string         `json:"pods"`
+	Restarts    int32            `json:"restarts"`
+	Termination *jsonTermination `json:"lastTermination,omitempty"`
+}
+
+type jsonTermination struct {
+	Reason   string `json:"reason"`
+	ExitCode int32  `json:"exitCode"`
+	Message  string `json:"message,omitempty"`
+}
+
+type jsonLogs struct {
+	Clusters []jsonLogCluster `json:"clusters"`
+	Pods     []string         `json:"pods"`
+	Lines    int              `json:"lines"`
+}
+
+type jsonLogCluster struct {
+	Container string `json:"container"`
+	Previous  bool   `json:"previous"`
+	Template  string `json:"template"`
+	Count     int    `json:"count"`
+}
+
+// JSONView implements View by writing one JSON object per snapshot (JSON Lines).
+type JSONView struct {
+	encoder *json.Encoder
+}
+
+// NewJSONView creates a new JSON Lines view
+func NewJSONView(writer io.Writer) *JSONView {
+	return &JSONView{encoder: json.NewEncoder(writer)}
+}
+
+// RenderSnapshot writes the snapshot as a single JSON line
+func (v *JSONView) RenderSnapshot(snapshot *types.RolloutSnapshot) {
+	_ = v.encoder.Encode(newJSONRecord(snapshot)) // stdout write errors not actionable
+}
+
+// Shutdown performs cleanup (no-op - every record is written immediately)
+func (v *JSONView) Shutdown() {}
+
+// Done implements types.View
+// Returns nil - JSON output is non-interactive, exits via Ctrl+C only
+func (v *JSONView) Done() <-chan struct{} {
+	return nil
+}
+
+// newJSONRecord converts a snapshot into its JSON Lines representation.
+func newJSONRecord(s *types.RolloutSnapshot) jsonRecord {
+	record := jsonRecord{
+		SchemaVersion:    JSONSchemaVersion,
+		Time:             s.SnapshotTime,
+		Kind:             string(s.Kind),
+		Name:             s.WorkloadName,
+		Revision:         s.NewRSName,
+		Status:           jsonStatus(s.Status),
+		StatusMessage:    s.StatusMessage,
+		Waiting:          s.Waiting,
+		RollbackRevision: s.RollbackRevision,
+		Strategy: jsonStrategy{
+			Type:           s.StrategyType,
+			MaxSurge:       s.MaxSurge,
+			MaxUnavailable: s.MaxUnavailable,
+			Partition:      s.Partition,
+		},
+		Replicas: jsonReplicas{
+			Desired: s.Desired,
+			Target:  s.UpdateTarget(),
+			New:     jsonReplicaState(s.NewRS),
+			Old:     jsonReplicaState(s.OldRS),
+		},
+		Progress:  jsonProgress{New: s.NewProgress, Old: s.OldProgress},
+		StartTime: s.StartTime,
+		Events: jsonEvents{
+			Clusters: make([]jsonEventCluster, 0, len(s.Events.Clusters)),
+			Ignored:  s.Events.IgnoredCount,
+		},
+		Problems: make([]jsonProblem, 0, len(s.Problems)),
+		Logs: jsonLogs{
+			Clusters: make([]jsonLogCluster, 0, len(s.Logs.Clusters)),
+			Pods:     append([]string{}, s.Logs.Pods...),
+			Lines:    s.Logs.Lines,
+		},
+	}
+
+	end := s.SnapshotTime
+	if s.Status.IsDone() && s.ProgressUpdateTime != nil {
+		end = *s.ProgressUpdateTime
+	}
+
+	record.DurationSeconds = end.Sub(s.StartTime).Round(time.Second).Seconds()
+
+	if s.EstimatedCompletion != nil {
+		remaining := s.EstimatedCompletion.Sub(s.SnapshotTime).Round(time.Second).Seconds()
+		record.ETA = s.EstimatedCompletion
+		record.ETASeconds = &remaining
+	}
+
+	for _, c := range s.Events.Clusters {
+		record.Events.Clusters = append(record.Events.Clusters, jsonEventCluster{
+			Source:   c.Source,
+			Type:     c.Type,
+			Reason:   c.Reason,
+			Message:  c.Message,
+			Count:    c.ExemplarCount,
+			LastSeen: c.LastSeen,
+		})
+	}
+
+	for _, p := range s.Problems {
+		record.Problems = append(record.Problems, jsonProblem{
+			Container:   p.Container,
+			Reason:      p.Reason,
+			Message:     p.Message,
+			Pods:        p.Pods,
+			Restarts:    p.Restarts,
+			Termination: (*jsonTermination)(p.Termination),
+		})
+	}
+
+	for _, c := range s.Logs.Clusters {
+		record.Logs.Clusters = append(record.Logs.Clusters, jsonLogCluster(c))
+	}
+
+	return record
+}
+
+// jsonStatus converts RolloutStatus to its stable JSON name.
+func jsonStatus(status types.RolloutStatus) string {
+	switch status {
+	case types.StatusProgressing:
+		return "progressing"
+	case types.StatusDeadlineExceeded:
+		return "deadline_exceeded"
+	case types.StatusComplete:
+		return "complete"
+	case types.StatusPaused:
+		return "paused"
+	case types.StatusReplicaFailure:
+		return "replica_failure"
+	case types.StatusSuperseded:
+		return "superseded"
+	default:
+		return "unknown"
+	}
+}
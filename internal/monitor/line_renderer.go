@@ -5,6 +5,7 @@ package monitor
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
@@ -32,9 +33,34 @@ func NewLineRenderer(config Config, output io.Writer) *LineRenderer {
 
 // RenderSnapshot outputs a single timestamped status line followed by any events
 func (r *LineRenderer) RenderSnapshot(snapshot *types.RolloutSnapshot) {
+	// Workloads without rollout data yet get a single line explaining why
+	if snapshot.Waiting {
+		fmt.Fprintf(r.output, "%s … [%s %s] [ROLLOUT WAITING] %s\n\n", //nolint:errcheck // stdout write errors not actionable
+			r.formatTimestamp(snapshot.SnapshotTime), strings.ToUpper(string(snapshot.Kind)),
+			snapshot.WorkloadName, truncateMessage(snapshot.StatusMessage, maxMessageLength))
+
+		return
+	}
+
 	statusLine := r.formatStatusLine(snapshot)
 	fmt.Fprintln(r.output, statusLine) //nolint:errcheck // stdout write errors not actionable
 
+	// Explain a halted rollout right under its status
+	if snapshot.StatusMessage != "" {
+		fmt.Fprintf(r.output, "         └─ %s %s %s\n", //nolint:errcheck // stdout write errors not actionable
+			r.formatSymbol(snapshot.Status), formatStatus(snapshot.Status),
+			truncateMessage(snapshot.StatusMessage, maxMessageLength))
+	}
+
+	// Render container problems before events, they explain a stalled rollout directly
+	for _, line := range r.formatProblems(snapshot.Problems) {
+		fmt.Fprintln(r.output, line) //nolint:errcheck // stdout write errors not actionable
+	}
+
+	for _, line := range r.formatLogs(snapshot.Logs) {
+		fmt.Fprintln(r.output, line) //nolint:errcheck // stdout write errors not actionable
+	}
+
 	// Render events if any
 	eventLines := r.formatEvents(snapshot.Events)
 	for _, line := range eventLines {
@@ -52,16 +78,28 @@ func (r *LineRenderer) formatTimestamp(t time.Time) string {
 
 // formatStatusLine generates the main status line
 // Format: <timestamp> <symbol> [REPLICASET X] [ROLLOUT STATUS] [NEW X/Y] [OLD X/Y] [ETA/DUR]
+// StatefulSets and DaemonSets show [REVISION X] instead of [REPLICASET X].
+// When several workloads are monitored, [<KIND> NAME] is inserted after the symbol.
+// After an automatic rollback, [ROLLBACK TO REVISION N] precedes the ETA/DUR.
 func (r *LineRenderer) formatStatusLine(snapshot *types.RolloutSnapshot) string {
 	symbol := r.formatSymbol(snapshot.Status)
 	timestamp := r.formatTimestamp(snapshot.SnapshotTime)
-	status := r.formatStatus(snapshot.Status)
+	revisionLabel := strings.ToUpper(snapshot.Kind.RevisionLabel())
+	status := formatStatus(snapshot.Status)
 	replicas := r.formatReplicaCounts(snapshot)
 	metadata := r.formatMetadata(snapshot)
 
+	if r.config.MultiTarget {
+		symbol += fmt.Sprintf(" [%s %s]", strings.ToUpper(string(snapshot.Kind)), snapshot.WorkloadName)
+	}
+
+	if snapshot.RollbackRevision > 0 {
+		metadata = fmt.Sprintf("[ROLLBACK TO REVISION %d] %s", snapshot.RollbackRevision, metadata)
+	}
+
 	return fmt.Sprintf(
-		"%s %s [REPLICASET %s] [ROLLOUT %s] %s %s",
-		timestamp, symbol, snapshot.NewRSName, status, replicas, metadata,
+		"%s %s [%s %s] [ROLLOUT %s] %s %s",
+		timestamp, symbol, revisionLabel, snapshot.NewRSName, status, replicas, metadata,
 	)
 }
 
@@ -74,13 +112,19 @@ func (r *LineRenderer) formatSymbol(status types.RolloutStatus) string {
 		return "✗"
 	case types.StatusComplete:
 		return "✓"
+	case types.StatusPaused:
+		return "⏸"
+	case types.StatusReplicaFailure:
+		return "⚠"
+	case types.StatusSuperseded:
+		return "✗"
 	default:
 		return "?"
 	}
 }
 
 // formatStatus converts RolloutStatus enum to CAPS status word (matches K8s condition naming)
-func (r *LineRenderer) formatStatus(status types.RolloutStatus) string {
+func formatStatus(status types.RolloutStatus) string {
 	switch status {
 	case types.StatusProgressing:
 		return "PROGRESSING"
@@ -88,23 +132,29 @@ func (r *LineRenderer) formatStatus(status types.RolloutStatus) string {
 		return "DEADLINE-EXCEEDED"
 	case types.StatusComplete:
 		return "COMPLETE"
+	case types.StatusPaused:
+		return "PAUSED"
+	case types.StatusReplicaFailure:
+		return "REPLICA-FAILURE"
+	case types.StatusSuperseded:
+		return "SUPERSEDED"
 	default:
 		return "UNKNOWN"
 	}
 }
 
 // formatReplicaCounts formats replica counts for NEW and OLD ReplicaSets
-// Format: [NEW X/Y] [OLD X/Y]
+// Format: [NEW X/Y] [OLD X/Y] (NEW is relative to the update target for partitioned rollouts)
 func (r *LineRenderer) formatReplicaCounts(snapshot *types.RolloutSnapshot) string {
 	return fmt.Sprintf("[NEW %d/%d] [OLD %d/%d]",
-		snapshot.NewRS.Available, snapshot.Desired,
+		snapshot.NewRS.Available, snapshot.UpdateTarget(),
 		snapshot.OldRS.Available, snapshot.Desired)
 }
 
 // formatMetadata formats contextual metadata (ETA or DUR) in bracketed format
 func (r *LineRenderer) formatMetadata(snapshot *types.RolloutSnapshot) string {
 	// Show actual completion duration if rollout is done and we have ProgressUpdateTime
-	if snapshot.Status.IsDone() {
+	if snapshot.Status.IsDone() && snapshot.ProgressUpdateTime != nil {
 		elapsed := snapshot.ProgressUpdateTime.Sub(snapshot.StartTime)
 
 		return fmt.Sprintf("[DUR %s]", types.FormatDuration(elapsed))
@@ -122,6 +172,7 @@ func (r *LineRenderer) formatMetadata(snapshot *types.RolloutSnapshot) string {
 }
 
 // formatEvents formats event lines with tree connector style for line mode.
+// Events of workload objects carry their source: └─ ⚠ replicaset/web-5d8f FailedCreate: ...
 func (r *LineRenderer) formatEvents(report types.EventSummary) []string {
 	if len(report.Clusters) == 0 {
 		return nil
@@ -132,8 +183,13 @@ func (r *LineRenderer) formatEvents(report types.EventSummary) []string {
 	for _, c := range report.Clusters {
 		age := types.FormatDuration(time.Since(c.LastSeen)) + " ago"
 		msg := truncateMessage(c.Message, maxMessageLength)
+		reason := c.Reason
+		if !c.IsPodEvent() {
+			reason = c.Source + " " + reason // Pod events stay unlabelled, they are the common case
+		}
+
 		line := fmt.Sprintf("         └─ %s %s: %s (%d exemplars, last %s)",
-			c.Symbol(), c.Reason, msg, c.ExemplarCount, age)
+			c.Symbol(), reason, msg, c.ExemplarCount, age)
 		result = append(result, line)
 	}
 
@@ -144,6 +200,60 @@ func (r *LineRenderer) formatEvents(report types.EventSummary) []string {
 	return result
 }
 
+// formatProblems formats container problem lines with tree connector style for line mode.
+// Format: └─ ✗ PROBLEM <reason> [<container>]: <message> (<pods>, <restarts>, last <reason> exit <code>)
+func (r *LineRenderer) formatProblems(problems []types.ContainerProblem) []string {
+	result := make([]string, 0, len(problems))
+
+	for _, p := range problems {
+		line := fmt.Sprintf("         └─ ✗ PROBLEM %s [%s]", p.Reason, p.Container)
+
+		if msg := p.DisplayMessage(); msg != "" {
+			line += ": " + truncateMessage(msg, maxMessageLength)
+		}
+
+		result = append(result, line+" ("+strings.Join(problemDetails(p), ", ")+")")
+	}
+
+	return result
+}
+
+// problemDetails lists affected pods, restart count and last termination of a container problem.
+func problemDetails(p types.ContainerProblem) []string {
+	details := []string{"pod " + p.Pods[0]}
+	if len(p.Pods) > 1 {
+		details[0] = fmt.Sprintf("%d pods", len(p.Pods))
+	}
+
+	if p.Restarts > 0 {
+		details = append(details, fmt.Sprintf("%d restarts", p.Restarts))
+	}
+
+	if p.Termination != nil {
+		details = append(details, fmt.Sprintf("last %s exit %d", p.Termination.Reason, p.Termination.ExitCode))
+	}
+
+	return details
+}
+
+// formatLogs formats clustered log templates of failing containers with tree connector style.
+// Format: └─ ≡ LOG [<container>] <template> (<count> lines)
+func (r *LineRenderer) formatLogs(summary types.LogSummary) []string {
+	result := make([]string, 0, len(summary.Clusters))
+
+	for _, c := range summary.Clusters {
+		container := c.Container
+		if c.Previous {
+			container += " previous"
+		}
+
+		result = append(result, fmt.Sprintf("         └─ ≡ LOG [%s] %s (%d lines)",
+			container, truncateMessage(c.Template, maxMessageLength), c.Count))
+	}
+
+	return result
+}
+
 // truncateMessage shortens a message to maxLen, adding ellipsis if truncated.
 func truncateMessage(msg string, maxLen int) string {
 	if len(msg) <= maxLen {
@@ -0,0 +1,251 @@
+package monitor
+
+// This file contains log sampling and clustering for failing containers of new pods.
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/faceair/drain"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// logFetchTimeout bounds a single log request so a slow API server cannot stall snapshots.
+const logFetchTimeout = 5 * time.Second
+
+// logSource identifies one container instance whose logs are sampled.
+type logSource struct {
+	pod       string
+	container string
+	previous  bool // Previous, terminated instance of the container
+}
+
+// logSample is a cached log fetch for a source.
+type logSample struct {
+	restarts int32 // Restart count at fetch time, a restart invalidates the sample
+	fetched  time.Time
+	lines    []string
+}
+
+// sourceLines pairs a log source with its sampled lines.
+type sourceLines struct {
+	source logSource
+	lines  []string
+}
+
+// logCollector fetches and caches logs of failing containers for one workload.
+// Logs are re-fetched when a container restarts or the sample gets older than
+// DefaultLogRefreshSeconds, so frequent snapshots do not hammer the API server.
+type logCollector struct {
+	repo      RolloutSource
+	tailLines int64
+	samples   map[logSource]logSample
+}
+
+// newLogCollector creates a log collector fetching tailLines lines per container instance.
+func newLogCollector(repo RolloutSource, tailLines int64) *logCollector {
+	return &logCollector{
+		repo:      repo,
+		tailLines: tailLines,
+		samples:   make(map[logSource]logSample),
+	}
+}
+
+// collect samples logs of failing containers among pods, at most DefaultMaxLogPods pods.
+// Returns the sampled lines in pod/container order and the names of sampled pods.
+// Fetch errors (e.g., missing pods/log RBAC permission) leave the source empty.
+func (l *logCollector) collect(ctx context.Context, pods []*corev1.Pod, now time.Time) ([]sourceLines, []string) {
+	pods = slices.Clone(pods)
+	slices.SortFunc(pods, func(a, b *corev1.Pod) int { return strings.Compare(a.Name, b.Name) })
+
+	var (
+		result  []sourceLines
+		sampled []string
+	)
+
+	samples := make(map[logSource]logSample)
+
+	for _, pod := range pods {
+		if len(sampled) == DefaultMaxLogPods {
+			break
+		}
+
+		failing := failingContainers(pod, now)
+		if len(failing) == 0 {
+			continue
+		}
+
+		sampled = append(sampled, pod.Name)
+
+		for _, status := range failing {
+			for _, source := range containerLogSources(pod.Name, status) {
+				sample := l.sample(ctx, source, status.RestartCount, now)
+				samples[source] = sample
+				result = append(result, sourceLines{source: source, lines: sample.lines})
+			}
+		}
+	}
+
+	l.samples = samples // Drop samples of pods that recovered or went away
+
+	return result, sampled
+}
+
+// sample returns the cached sample for a source, fetching it again if stale.
+func (l *logCollector) sample(ctx context.Context, source logSource, restarts int32, now time.Time) logSample {
+	cached, ok := l.samples[source]
+	if ok && cached.restarts == restarts && now.Sub(cached.fetched) < DefaultLogRefreshSeconds*time.Second {
+		return cached
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, logFetchTimeout)
+	defer cancel()
+
+	lines, err := l.repo.GetContainerLogs(fetchCtx, source.pod, source.container, source.previous, l.tailLines)
+	if err != nil {
+		lines = nil
+	}
+
+	return logSample{restarts: restarts, fetched: now, lines: lines}
+}
+
+// failingContainers returns statuses of containers worth reading logs from: those reported
+// as problems, and running containers that stay unready past LogReadinessGraceSeconds.
+func failingContainers(pod *corev1.Pod, now time.Time) []corev1.ContainerStatus {
+	if pod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var failing []corev1.ContainerStatus
+
+	for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+		if _, ok := containerProblem(status); ok {
+			failing = append(failing, status)
+
+			continue
+		}
+
+		running := status.State.Running
+		if running != nil && !status.Ready &&
+			now.Sub(running.StartedAt.Time) >= LogReadinessGraceSeconds*time.Second {
+			failing = append(failing, status)
+		}
+	}
+
+	return failing
+}
+
+// containerLogSources lists the container instances with logs: the current one unless it
+// is waiting to (re)start, and the previous one if the container has restarted.
+func containerLogSources(podName string, status corev1.ContainerStatus) []logSource {
+	var sources []logSource
+
+	if status.State.Waiting == nil {
+		sources = append(sources, logSource{pod: podName, container: status.Name})
+	}
+
+	if status.RestartCount > 0 {
+		sources = append(sources, logSource{pod: podName, container: status.Name, previous: true})
+	}
+
+	return sources
+}
+
+// summarizeLogs clusters sampled log lines with Drain, separately per container instance kind
+// (current or previous), and keeps the DefaultMaxLogClusters most frequent templates.
+func summarizeLogs(samples []sourceLines, pods []string, threshold float64) types.LogSummary {
+	type groupKey struct {
+		container string
+		previous  bool
+	}
+
+	var keys []groupKey
+
+	groups := make(map[groupKey][]string)
+	total := 0
+
+	for _, sample := range samples {
+		key := groupKey{container: sample.source.container, previous: sample.source.previous}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		for _, line := range sample.lines {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			groups[key] = append(groups[key], line)
+			total++
+		}
+	}
+
+	var clusters []types.LogCluster
+
+	for _, key := range keys {
+		lines := groups[key]
+		if len(lines) == 0 {
+			continue
+		}
+
+		var order []*drain.LogCluster
+
+		counts := make(map[*drain.LogCluster]int)
+
+		for _, cluster := range trainDrain(lines, threshold) {
+			if counts[cluster] == 0 {
+				order = append(order, cluster)
+			}
+
+			counts[cluster]++
+		}
+
+		for _, cluster := range order {
+			clusters = append(clusters, types.LogCluster{
+				Container: key.container,
+				Previous:  key.previous,
+				Template:  extractTemplate(cluster.String()),
+				Count:     counts[cluster],
+			})
+		}
+	}
+
+	// Sort: by count, previous instance first (it holds the crash), then by container
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if clusters[i].Count != clusters[j].Count {
+			return clusters[i].Count > clusters[j].Count
+		}
+
+		if clusters[i].Previous != clusters[j].Previous {
+			return clusters[i].Previous
+		}
+
+		return clusters[i].Container < clusters[j].Container
+	})
+
+	if len(clusters) > DefaultMaxLogClusters {
+		clusters = clusters[:DefaultMaxLogClusters]
+	}
+
+	return types.LogSummary{Clusters: clusters, Pods: pods, Lines: total}
+}
+
+// summarizeFailingLogs samples and clusters logs of failing pods for a tracker.
+// Returns an empty summary when log collection is disabled.
+func (c *Controller) summarizeFailingLogs(
+	ctx context.Context,
+	t *rolloutTracker,
+	pods []*corev1.Pod,
+) types.LogSummary {
+	if t.logs == nil {
+		return types.LogSummary{}
+	}
+
+	samples, sampled := t.logs.collect(ctx, pods, time.Now())
+
+	return summarizeLogs(samples, sampled, c.config.SimilarityThreshold)
+}
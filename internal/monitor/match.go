@@ -0,0 +1,108 @@
+package monitor
+
+// This file contains matching of deployment rollouts against an expected revision, image or generation.
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// RolloutMatch pins the deployment rollout to wait for (--revision, --image, --generation).
+// Zero fields match any rollout. Earlier rollouts are reported as pending and never complete.
+type RolloutMatch struct {
+	Revision   int64  // Deployment revision of the new ReplicaSet
+	Image      string // Image one of the new ReplicaSet's containers must run
+	Generation int64  // Deployment generation the controller must have observed
+}
+
+// IsZero reports whether no rollout is pinned.
+func (m RolloutMatch) IsZero() bool {
+	return m == RolloutMatch{}
+}
+
+// String describes the awaited rollout, e.g. "image repo:tag, generation 7".
+func (m RolloutMatch) String() string {
+	var parts []string
+
+	if m.Revision > 0 {
+		parts = append(parts, fmt.Sprintf("revision %d", m.Revision))
+	}
+
+	if m.Image != "" {
+		parts = append(parts, "image "+m.Image)
+	}
+
+	if m.Generation > 0 {
+		parts = append(parts, fmt.Sprintf("generation %d", m.Generation))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// matchesReplicaSet reports whether a ReplicaSet carries the awaited revision and image.
+func (m RolloutMatch) matchesReplicaSet(rs *appsv1.ReplicaSet, revision int64) bool {
+	if m.Revision > 0 && revision != m.Revision {
+		return false
+	}
+
+	return m.Image == "" || slices.Contains(replicaSetImages(rs), m.Image)
+}
+
+// replicaSetImages lists the images of a ReplicaSet's init and regular containers.
+func replicaSetImages(rs *appsv1.ReplicaSet) []string {
+	spec := rs.Spec.Template.Spec
+
+	images := make([]string, 0, len(spec.InitContainers)+len(spec.Containers))
+	for _, container := range slices.Concat(spec.InitContainers, spec.Containers) {
+		images = append(images, container.Image)
+	}
+
+	return images
+}
+
+// matchState is where the awaited rollout stands relative to the newest ReplicaSet.
+type matchState int
+
+const (
+	matchPending    matchState = iota // Awaited rollout has not started yet
+	matchActive                       // Newest ReplicaSet is the awaited rollout
+	matchSuperseded                   // A newer rollout replaced the awaited one
+)
+
+// matchRollout compares the newest ReplicaSet with the awaited rollout.
+// A rollout is superseded once a higher revision than the awaited one exists, or when the
+// newest ReplicaSet changes after matching, so a concurrent change is never reported as complete.
+func (t *rolloutTracker) matchRollout(
+	match RolloutMatch,
+	deployment *appsv1.Deployment,
+	newRS *appsv1.ReplicaSet,
+) (matchState, error) {
+	// Any newer ReplicaSet replaces the matched one, even if it also matches
+	if t.matchedRSName != "" && t.matchedRSName != newRS.Name {
+		return matchSuperseded, nil
+	}
+
+	revision, err := replicaSetRevision(newRS)
+	if err != nil {
+		return matchPending, err
+	}
+
+	if match.Generation > 0 && deployment.Status.ObservedGeneration < match.Generation {
+		return matchPending, nil
+	}
+
+	if !match.matchesReplicaSet(newRS, revision) {
+		if match.Revision > 0 && revision > match.Revision {
+			return matchSuperseded, nil
+		}
+
+		return matchPending, nil
+	}
+
+	t.matchedRSName = newRS.Name
+
+	return matchActive, nil
+}
@@ -0,0 +1,193 @@
+package monitor
+
+// This file contains the Prometheus metrics exported while watching (--metrics-addr).
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// metricsNamespace prefixes every exported metric name.
+const metricsNamespace = "kubectl_watch_rollout"
+
+// metricsReadHeaderTimeout bounds how long a scraper may take to send request headers.
+const metricsReadHeaderTimeout = 5 * time.Second
+
+// metricsStatuses lists the values of the status state set, one series each.
+var metricsStatuses = []types.RolloutStatus{
+	types.StatusProgressing, types.StatusComplete, types.StatusDeadlineExceeded,
+	types.StatusPaused, types.StatusReplicaFailure, types.StatusSuperseded,
+}
+
+// apiErrorReporter is implemented by sources that can report failed API requests,
+// including watch failures the informers retry on their own.
+type apiErrorReporter interface {
+	SetAPIErrorHandler(handler func(operation string))
+}
+
+// rolloutMetrics holds the gauges derived from rendered snapshots and the watcher's counters.
+// Gauges carry "kind" and "name" labels identifying the workload.
+type rolloutMetrics struct {
+	registry *prometheus.Registry
+
+	desired       *prometheus.GaugeVec
+	replicas      *prometheus.GaugeVec // Additional labels: "replicaset" (new, old), "state" (current, ready, available)
+	progress      *prometheus.GaugeVec
+	eta           *prometheus.GaugeVec // Only set while an estimate exists
+	status        *prometheus.GaugeVec // State set: 1 for the current status, 0 for the others
+	warningEvents *prometheus.GaugeVec // Additional label: "reason"
+
+	polls     *prometheus.CounterVec
+	apiErrors *prometheus.CounterVec // Label: "operation" (watch, logs, rollback)
+
+	workloads map[Target]bool // Workloads with series, deleted once they are no longer monitored
+}
+
+// newRolloutMetrics creates and registers the metrics, along with Go runtime and process metrics.
+func newRolloutMetrics() *rolloutMetrics {
+	workload := []string{"kind", "name"}
+
+	gauge := func(name, help string, labels ...string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      name,
+			Help:      help,
+		}, slices.Concat(workload, labels))
+	}
+
+	m := &rolloutMetrics{
+		registry: prometheus.NewRegistry(),
+		desired:  gauge("desired_replicas", "Desired replicas of the workload."),
+		replicas: gauge("replicas",
+			"Pods of the new and old ReplicaSets (or revisions) by lifecycle state.", "replicaset", "state"),
+		progress: gauge("progress_ratio", "Share of the rollout target available on the new revision (0-1)."),
+		eta:      gauge("eta_seconds", "Estimated seconds until the rollout completes, absent without an estimate."),
+		status:   gauge("status", "Rollout status, 1 for the current status and 0 for the others.", "status"),
+		warningEvents: gauge("warning_events",
+			"Warning events in the rollout's event clusters by reason.", "reason"),
+		polls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "polls_total",
+			Help:      "Snapshots built from the watch cache.",
+		}, workload),
+		apiErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "api_errors_total",
+			Help:      "Failed Kubernetes API requests by operation (watch, logs, rollback).",
+		}, []string{"operation"}),
+		workloads: make(map[Target]bool),
+	}
+
+	m.registry.MustRegister(
+		m.desired, m.replicas, m.progress, m.eta, m.status, m.warningEvents, m.polls, m.apiErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// observe updates the gauges of a workload from its latest snapshot.
+func (m *rolloutMetrics) observe(s *types.RolloutSnapshot) {
+	kind, name := strings.ToLower(string(s.Kind)), s.WorkloadName
+
+	m.desired.WithLabelValues(kind, name).Set(float64(s.Desired))
+	m.progress.WithLabelValues(kind, name).Set(s.NewProgress)
+
+	for replicaSet, state := range map[string]types.ReplicaSetState{"new": s.NewRS, "old": s.OldRS} {
+		m.replicas.WithLabelValues(kind, name, replicaSet, "current").Set(float64(state.Current))
+		m.replicas.WithLabelValues(kind, name, replicaSet, "ready").Set(float64(state.Ready))
+		m.replicas.WithLabelValues(kind, name, replicaSet, "available").Set(float64(state.Available))
+	}
+
+	if s.EstimatedCompletion != nil {
+		m.eta.WithLabelValues(kind, name).Set(max(0, s.EstimatedCompletion.Sub(s.SnapshotTime).Seconds()))
+	} else {
+		m.eta.DeleteLabelValues(kind, name)
+	}
+
+	for _, status := range metricsStatuses {
+		value := 0.0
+		if status == s.Status {
+			value = 1
+		}
+
+		m.status.WithLabelValues(kind, name, jsonStatus(status)).Set(value)
+	}
+
+	// Reasons come and go with the event clusters, stale series would report old warnings forever
+	m.warningEvents.DeletePartialMatch(prometheus.Labels{"kind": kind, "name": name})
+
+	for _, cluster := range s.Events.Clusters {
+		if cluster.Type == corev1.EventTypeWarning {
+			m.warningEvents.WithLabelValues(kind, name, cluster.Reason).Add(float64(cluster.ExemplarCount))
+		}
+	}
+}
+
+// poll counts a snapshot build for a workload.
+func (m *rolloutMetrics) poll(target Target) {
+	m.workloads[target] = true
+	m.polls.WithLabelValues(strings.ToLower(string(target.Kind)), target.Name).Inc()
+}
+
+// retain deletes the series of workloads not among targets, such as deployments that no longer
+// match the selector or were deleted, so they do not report their last state forever.
+func (m *rolloutMetrics) retain(targets []Target) {
+	for target := range m.workloads {
+		if slices.Contains(targets, target) {
+			continue
+		}
+
+		labels := prometheus.Labels{"kind": strings.ToLower(string(target.Kind)), "name": target.Name}
+
+		for _, vec := range []interface{ DeletePartialMatch(prometheus.Labels) int }{
+			m.desired, m.replicas, m.progress, m.eta, m.status, m.warningEvents, m.polls,
+		} {
+			vec.DeletePartialMatch(labels)
+		}
+
+		delete(m.workloads, target)
+	}
+}
+
+// apiError counts a failed API request.
+func (m *rolloutMetrics) apiError(operation string) {
+	m.apiErrors.WithLabelValues(operation).Inc()
+}
+
+// serve exposes the metrics on addr at /metrics until ctx is cancelled.
+// Returns once the listener is bound, so an unusable address is reported before watching starts.
+func (m *rolloutMetrics) serve(ctx context.Context, addr string) error {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return Classify(ErrInvalidArguments, fmt.Errorf("failed to listen for metrics on '%s': %w", addr, err))
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close() // Scrapes in flight are cut off, the watcher is exiting anyway
+	}()
+
+	go func() {
+		_ = server.Serve(listener) // Only returns once closed, the listener is already bound
+	}()
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package monitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TestMetricsRetain checks that series of workloads leaving the target set are deleted
+// while the series of the remaining workloads are kept.
+func TestMetricsRetain(t *testing.T) {
+	m := newRolloutMetrics()
+
+	web := Target{Kind: types.KindDeployment, Name: "web"}
+	api := Target{Kind: types.KindDeployment, Name: "api"}
+
+	for _, target := range []Target{web, api} {
+		eta := time.Now().Add(time.Minute)
+
+		m.poll(target)
+		m.observe(&types.RolloutSnapshot{
+			Kind:                target.Kind,
+			WorkloadName:        target.Name,
+			SnapshotTime:        time.Now(),
+			EstimatedCompletion: &eta,
+			Events: types.EventSummary{Clusters: []types.EventCluster{
+				{Type: corev1.EventTypeWarning, Reason: "BackOff", ExemplarCount: 1},
+			}},
+		})
+	}
+
+	m.retain([]Target{api})
+
+	for name, want := range map[string]int{
+		"desired_replicas": 1,
+		"replicas":         6,
+		"progress_ratio":   1,
+		"eta_seconds":      1,
+		"status":           len(metricsStatuses),
+		"warning_events":   1,
+		"polls_total":      1,
+	} {
+		got := testutil.CollectAndCount(m.registry, metricsNamespace+"_"+name)
+		if got != want {
+			t.Errorf("%s has %d series, want %d of api only", name, got, want)
+		}
+	}
+
+	// A workload returning to the target set is observed afresh
+	m.poll(web)
+	m.retain([]Target{web, api})
+
+	if got := testutil.CollectAndCount(m.registry, metricsNamespace+"_polls_total"); got != 2 {
+		t.Errorf("polls_total has %d series, want 2", got)
+	}
+}
@@ -3,76 +3,208 @@ package monitor
 // Architecture (MVC Pattern):
 //   - Controller: Orchestrates monitoring logic and state management
 //   - View: Presentation layer interface (see view.go)
-//   - DeploymentRepository: Data access layer for Kubernetes API (see repository.go)
+//   - RolloutSource: Data access interface the controller reads workloads from (see source.go)
+//   - DeploymentRepository: Informer-backed RolloutSource for Kubernetes API (see repository.go)
 //   - Types: Domain models and DTOs (see types.go)
 //
-// Data Flow: Repository (K8s API) → Controller → Model (RolloutSnapshot) → View
+// Data Flow: Repository (K8s watch cache) → Controller → Model (RolloutSnapshot) → View
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/ivoronin/kubectl-watch-rollout/internal/tui"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 )
 
-// Controller handles deployment rollout monitoring (Controller layer).
+// Controller handles workload rollout monitoring (Controller layer).
 // It orchestrates between repository (data), view (presentation), and metrics (logic).
+// A single controller can follow several workloads; each gets its own rolloutTracker.
 type Controller struct {
-	repo           *DeploymentRepository
-	view           View
-	deploymentName string
-	config         Config
+	repo     RolloutSource
+	view     View
+	spec     TargetSpec
+	config   Config
+	trackers map[Target]*rolloutTracker
+	recorder *sessionRecorder // Session recording, nil when disabled
+	metrics  *rolloutMetrics  // Prometheus metrics, nil when disabled
+	notifier *webhookNotifier // Webhook notifications, nil when disabled
+
+	rolledBack bool  // Failed rollouts were rolled back, now watching the rollback
+	notifyErr  error // Notifications that failed, set once Run returns
+}
+
+// rolloutTracker holds per-workload state that persists across snapshots.
+type rolloutTracker struct {
+	target Target
 
 	// ETA smoothing state - only recalculate when progress changes
-	etaLastRSName string     // Reset ETA state on new rollout
-	etaLastAvail  int32      // Track when Available count changes
-	etaTarget     *time.Time // Absolute target time (counts down naturally)
+	etaLastRSName string       // Reset ETA state on new rollout
+	etaLastAvail  int32        // Track when Available count changes
+	etaTarget     *time.Time   // Absolute target time (counts down naturally)
+	eta           etaEstimator // Estimator of the current rollout (see ETAModel)
+
+	logs *logCollector // Log sampling for failing containers, nil when disabled
+
+	// Rollback state - set once the failed rollout was reverted
+	rollbackRevision   int64 // Revision rolled back to
+	rollbackGeneration int64 // Deployment generation carrying the rollback
+
+	sources []sessionObject // Raw objects of the last built snapshot, only captured while recording
+
+	notify  notifyState  // Transitions already reported to the webhook
+	history historyState // Rollout to record in the history once it finishes
+
+	matchedRSName string // Newest ReplicaSet once it matched the awaited rollout (see RolloutMatch)
+
+	lastSnapshot *types.RolloutSnapshot // Last rendered snapshot, used to skip duplicate renders
 }
 
-// New creates a new Controller instance for monitoring a deployment rollout
-func New(repo *DeploymentRepository, deploymentName string) (*Controller, error) {
-	return NewWithConfig(repo, deploymentName, DefaultConfig())
+// New creates a new Controller instance for monitoring workload rollouts
+func New(repo RolloutSource, spec TargetSpec) (*Controller, error) {
+	return NewWithConfig(repo, spec, DefaultConfig())
 }
 
 // NewWithConfig creates a new Controller instance with custom configuration.
-func NewWithConfig(repo *DeploymentRepository, deploymentName string, config Config) (*Controller, error) {
+func NewWithConfig(repo RolloutSource, spec TargetSpec, config Config) (*Controller, error) {
 	if repo == nil {
 		return nil, errors.New("internal error: repository is required")
 	}
 
-	if deploymentName == "" {
-		return nil, errors.New("deployment name is required")
+	if len(spec.Targets) == 0 && spec.Selector == nil {
+		return nil, errors.New("workload name or selector is required")
+	}
+
+	for _, target := range spec.Targets {
+		if target.Name == "" {
+			return nil, errors.New("workload name is required")
+		}
+	}
+
+	config.MultiTarget = spec.IsMulti()
+
+	view, err := newView(config)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Controller{
+		repo:     repo,
+		view:     view,
+		spec:     spec,
+		config:   config,
+		trackers: make(map[Target]*rolloutTracker),
+	}
+
+	if config.Record != nil {
+		c.recorder = newSessionRecorder(config.Record, config.MultiTarget)
 	}
 
-	var view View
-	if config.LineMode {
-		view = NewLineView(config, os.Stdout)
-	} else {
-		view = tui.NewView()
+	if config.NotifyWebhook != "" {
+		tmpl, err := ParseNotifyTemplate(cmp.Or(config.NotifyTemplate, DefaultNotifyTemplate))
+		if err != nil {
+			return nil, Classify(ErrInvalidArguments, err)
+		}
+
+		c.notifier = newWebhookNotifier(config.NotifyWebhook, tmpl, notifyContentType(config))
+	}
+
+	if config.MetricsAddr != "" {
+		c.metrics = newRolloutMetrics()
+
+		if reporter, ok := repo.(apiErrorReporter); ok {
+			reporter.SetAPIErrorHandler(c.metrics.apiError)
+		}
+	}
+
+	return c, nil
+}
+
+// newView creates the view for the configured output format.
+func newView(config Config) (View, error) {
+	out := config.Out
+	if out == nil {
+		out = os.Stdout
+	}
+
+	switch config.Output {
+	case OutputLine:
+		return NewLineView(config, out), nil
+	case OutputJSONL:
+		return NewJSONView(out), nil
+	case OutputTUI:
+		return tui.NewView(config.MultiTarget), nil
 	}
 
-	return &Controller{
-		repo:           repo,
-		view:           view,
-		deploymentName: deploymentName,
-		config:         config,
-	}, nil
+	return nil, fmt.Errorf("unsupported output format '%s'", config.Output)
+}
+
+// WithWatchTimeout returns a context cancelled after timeout with ErrWatchTimeout as its cause.
+// Apply it once around everything the --timeout budget covers, discovery included.
+func WithWatchTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeoutCause(ctx, timeout, ErrWatchTimeout)
 }
 
-// Run starts monitoring the deployment and returns error if monitoring fails
+// Run starts monitoring the workloads and returns error if monitoring fails.
+// Snapshots are rebuilt whenever the repository reports a change, debounced to
+// absorb bursts, and re-rendered periodically even when nothing changed.
+// With --until-complete, returns once every monitored rollout is done.
+// When ctx expires with ErrWatchTimeout as its cause (see WithWatchTimeout), returns
+// ErrWatchTimeout describing where unfinished rollouts are stuck.
 func (c *Controller) Run(ctx context.Context) error {
+	if c.notifier != nil {
+		defer func() { c.notifyErr = c.notifier.close(ctx) }()
+	}
+
 	defer c.view.Shutdown()
 
-	pollInterval := time.Duration(c.config.PollIntervalSeconds) * time.Second
+	if c.metrics != nil {
+		err := c.metrics.serve(ctx, c.config.MetricsAddr)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.repo.Start(ctx, c.spec.Kinds()...)
+	if err != nil && errors.Is(context.Cause(ctx), ErrWatchTimeout) {
+		return fmt.Errorf("%w after %s before the watch started: %v",
+			ErrWatchTimeout, types.FormatDuration(c.config.Timeout), err)
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return c.stopped(ctx)
+		}
+
+		return fmt.Errorf("failed to start watching: %w", err)
+	}
+
+	targets, err := c.resolveTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return fmt.Errorf("%w: no deployments match selector '%s'", ErrWorkloadNotFound, c.spec.Selector)
+	}
+
+	debounce := time.Duration(c.config.DebounceMilliseconds) * time.Millisecond
+	resyncInterval := time.Duration(c.config.ResyncIntervalSeconds) * time.Second
+
+	resync := time.NewTicker(resyncInterval)
+	defer resync.Stop()
 
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	force := true
 
 	for {
-		result, err := c.processDeployment(ctx)
+		result, err := c.processTargets(ctx, force)
 		if err != nil {
 			return err
 		}
@@ -80,37 +212,316 @@ func (c *Controller) Run(ctx context.Context) error {
 		if result.Done {
 			// Only exit if --until-complete flag is set
 			if c.config.UntilComplete {
-				if result.Failed {
-					return ErrProgressDeadlineExceeded
+				stop, err := c.finish(ctx, result)
+				if stop {
+					return err
 				}
-
-				return nil
 			}
 			// Default: continuous monitoring - continue loop
 		}
 
 		select {
 		case <-ctx.Done():
-			return errors.New("monitoring cancelled")
+			return c.stopped(ctx)
 		case <-c.view.Done():
 			return nil // User quit via TUI
-		case <-ticker.C:
+		case <-resync.C:
+			force = true
+		case <-c.repo.Updates():
+			force = false
+
+			// Let related changes (pod + RS + event) land before rebuilding
+			select {
+			case <-ctx.Done():
+				return c.stopped(ctx)
+			case <-time.After(debounce):
+			}
+		}
+	}
+}
+
+// NotificationError returns the webhook notifications that could not be rendered or
+// delivered, once Run has returned. Nil when notifications are disabled or all were delivered.
+func (c *Controller) NotificationError() error {
+	return c.notifyErr
+}
+
+// stopped returns the error for a cancelled context: a watch timeout with a diagnosis
+// of unfinished rollouts, or plain cancellation (Ctrl+C).
+// In continuous mode, a timeout with every rollout done ends monitoring successfully.
+func (c *Controller) stopped(ctx context.Context) error {
+	if !errors.Is(context.Cause(ctx), ErrWatchTimeout) {
+		return ErrCancelled
+	}
+
+	pending := c.pendingSnapshots()
+	if len(pending) == 0 && !c.config.UntilComplete && len(c.trackers) > 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w after %s, rollouts not complete:\n%s",
+		ErrWatchTimeout, types.FormatDuration(c.config.Timeout), diagnoseStuck(pending, time.Now()))
+}
+
+// pendingSnapshots returns the last snapshots of rollouts that are still progressing or failed.
+// Targets that never produced a snapshot are reported with a nil snapshot.
+func (c *Controller) pendingSnapshots() map[Target]*types.RolloutSnapshot {
+	pending := make(map[Target]*types.RolloutSnapshot)
+
+	for target, t := range c.trackers {
+		if t.lastSnapshot == nil || !t.lastSnapshot.Status.IsDone() || t.lastSnapshot.Status.IsFailed() {
+			pending[target] = t.lastSnapshot
+		}
+	}
+
+	return pending
+}
+
+// finish decides how --until-complete ends once every rollout is done.
+// With RollbackOnFailure, failed deployments are rolled back first and monitoring
+// continues until the rollback is done. Returns true with the outcome when monitoring should stop.
+func (c *Controller) finish(ctx context.Context, result RolloutResult) (bool, error) {
+	switch {
+	case result.Failed && c.config.RollbackOnFailure && !c.rolledBack:
+		rolledBack, err := c.rollbackFailed(ctx)
+		if err != nil || rolledBack == 0 {
+			return true, cmp.Or(err, result.Cause)
 		}
+
+		c.rolledBack = true
+
+		return false, nil
+	case result.Failed && c.rolledBack:
+		return true, fmt.Errorf("rollback did not complete: %w", result.Cause)
+	case result.Failed, result.Halted:
+		return true, result.Cause
+	case c.rolledBack:
+		return true, ErrRolledBack
 	}
+
+	return true, nil
 }
 
-// processDeployment fetches deployment data, builds snapshot, and renders view.
+// rollbackFailed reverts every failed deployment to its previous revision, like `kubectl rollout undo`.
+// Returns how many deployments were rolled back.
+func (c *Controller) rollbackFailed(ctx context.Context) (int, error) {
+	rolledBack := 0
+
+	for _, t := range c.trackers {
+		if t.target.Kind != types.KindDeployment || t.lastSnapshot == nil || !c.rollbackable(t.lastSnapshot.Status) {
+			continue
+		}
+
+		revision, generation, err := c.repo.RollbackDeployment(ctx, t.target.Name)
+		if err != nil {
+			return rolledBack, err
+		}
+
+		t.rollbackRevision = revision
+		t.rollbackGeneration = generation
+		rolledBack++
+	}
+
+	return rolledBack, nil
+}
+
+// rollbackable reports whether a deployment with this status failed in a way a rollback can fix.
+// Paused deployments cannot be rolled back, and superseded rollouts were replaced on purpose.
+func (c *Controller) rollbackable(status types.RolloutStatus) bool {
+	switch status {
+	case types.StatusDeadlineExceeded:
+		return true
+	case types.StatusReplicaFailure:
+		return c.rolloutResult(status).Failed
+	}
+
+	return false
+}
+
+// resolveTargets expands the target spec into the workloads to monitor right now.
+// Selector matches are re-evaluated on every call, so new deployments join automatically.
+func (c *Controller) resolveTargets(ctx context.Context) ([]Target, error) {
+	if c.spec.Selector == nil {
+		return c.spec.Targets, nil
+	}
+
+	names, err := c.repo.ListDeployments(ctx, c.spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := slices.Clone(c.spec.Targets)
+
+	for _, name := range names {
+		target := Target{Kind: types.KindDeployment, Name: name}
+		if !slices.Contains(targets, target) {
+			targets = append(targets, target)
+		}
+	}
+
+	return targets, nil
+}
+
+// tracker returns the persistent state for a target, creating it on first use.
+func (c *Controller) tracker(target Target) *rolloutTracker {
+	t, ok := c.trackers[target]
+	if !ok {
+		t = &rolloutTracker{target: target}
+		if c.config.LogTailLines > 0 {
+			t.logs = newLogCollector(c.repo, c.config.LogTailLines)
+		}
+
+		c.trackers[target] = t
+	}
+
+	return t
+}
+
+// processTargets processes every monitored workload and aggregates their results.
+// Done only when all rollouts are done; Failed if any of them failed.
+func (c *Controller) processTargets(ctx context.Context, force bool) (RolloutResult, error) {
+	targets, err := c.resolveTargets(ctx)
+	if err != nil {
+		return RolloutResult{}, err
+	}
+
+	if c.metrics != nil {
+		c.metrics.retain(targets)
+	}
+
+	result := RolloutResult{Done: len(targets) > 0}
+
+	for _, target := range targets {
+		r, err := c.processTarget(ctx, c.tracker(target), force)
+		if err != nil {
+			return RolloutResult{}, err
+		}
+
+		if r.Failed && !result.Failed || result.Cause == nil {
+			result.Cause = r.Cause // Failures take precedence over halts
+		}
+
+		result.Done = result.Done && r.Done
+		result.Failed = result.Failed || r.Failed
+		result.Halted = result.Halted || r.Halted
+	}
+
+	return result, nil
+}
+
+// processTarget builds a snapshot from cached data and renders it.
+// Unchanged snapshots are skipped unless force is set, so unrelated namespace
+// activity does not produce duplicate output.
+// Targets whose data is not fully cached yet are shown as waiting until the next change,
+// as are deployments matched by the selector that no longer exist, so they do not end
+// monitoring of the others. Explicitly named workloads that do not exist are an error.
 // Returns rollout result indicating done/failed state, or error if processing fails.
-func (c *Controller) processDeployment(ctx context.Context) (RolloutResult, error) {
-	snapshot, err := c.buildSnapshot(ctx)
+func (c *Controller) processTarget(ctx context.Context, t *rolloutTracker, force bool) (RolloutResult, error) {
+	snapshot, err := c.buildSnapshot(ctx, t)
+	if c.metrics != nil {
+		c.metrics.poll(t.target)
+	}
+
+	if errors.Is(err, errSnapshotPending) ||
+		errors.Is(err, ErrWorkloadNotFound) && !slices.Contains(c.spec.Targets, t.target) {
+		c.renderWaiting(t, err, force)
+
+		return RolloutResult{}, nil
+	}
+
 	if err != nil {
-		return RolloutResult{}, fmt.Errorf("failed to build snapshot: %w", err)
+		return RolloutResult{}, fmt.Errorf("failed to build snapshot for %s '%s': %w",
+			strings.ToLower(string(t.target.Kind)), t.target.Name, err)
+	}
+
+	if c.config.History != nil {
+		c.recordHistory(t, snapshot)
+	}
+
+	snapshot.EstimatedCompletion = t.updateETA(snapshot, c.config.ETAModel)
+
+	if c.metrics != nil {
+		c.metrics.observe(snapshot)
+	}
+
+	if c.notifier != nil {
+		for _, event := range t.notify.transitions(snapshot, c.config.StallTimeout) {
+			c.notifier.notify(newNotification(event, snapshot))
+		}
+	}
+
+	if force || !sameSnapshot(snapshot, t.lastSnapshot) {
+		c.view.RenderSnapshot(snapshot)
+		t.lastSnapshot = snapshot
+
+		if c.recorder != nil {
+			err := c.recorder.record(snapshot, t.sources)
+			if err != nil {
+				return RolloutResult{}, fmt.Errorf("failed to record snapshot: %w", err)
+			}
+		}
+	}
+
+	return c.rolloutResult(snapshot.Status), nil
+}
+
+// renderWaiting renders a placeholder snapshot for a workload whose snapshot could not be built.
+// It is neither recorded nor reported, and is rendered again only when the cause changes.
+func (c *Controller) renderWaiting(t *rolloutTracker, cause error, force bool) {
+	snapshot := &types.RolloutSnapshot{
+		Kind:          t.target.Kind,
+		WorkloadName:  t.target.Name,
+		SnapshotTime:  time.Now(),
+		Status:        types.StatusProgressing,
+		StatusMessage: cause.Error(),
+		Waiting:       true,
+	}
+
+	if force || !sameSnapshot(snapshot, t.lastSnapshot) {
+		c.view.RenderSnapshot(snapshot)
+		t.lastSnapshot = snapshot
+	}
+}
+
+// rolloutResult maps a rollout status to its result, applying the halt policies to halted rollouts.
+func (c *Controller) rolloutResult(status types.RolloutStatus) RolloutResult {
+	var (
+		policy HaltPolicy
+		cause  error
+	)
+
+	switch status {
+	case types.StatusDeadlineExceeded:
+		return RolloutResult{Done: true, Failed: true, Cause: ErrProgressDeadlineExceeded}
+	case types.StatusSuperseded:
+		return RolloutResult{Done: true, Failed: true, Cause: ErrRolloutSuperseded}
+	case types.StatusPaused:
+		policy, cause = c.config.OnPaused, ErrRolloutPaused
+	case types.StatusReplicaFailure:
+		policy, cause = c.config.OnReplicaFailure, ErrReplicaFailure
+	default:
+		return RolloutResult{Done: status.IsDone()}
+	}
+
+	switch policy {
+	case HaltFail:
+		return RolloutResult{Done: true, Failed: true, Cause: fmt.Errorf("%w: %w", ErrRolloutFailed, cause)}
+	case HaltExit:
+		return RolloutResult{Done: true, Halted: true, Cause: fmt.Errorf("%w: %w", ErrRolloutHalted, cause)}
+	case HaltWait:
+	}
+
+	return RolloutResult{}
+}
+
+// sameSnapshot reports whether two snapshots differ only in SnapshotTime.
+func sameSnapshot(a, b *types.RolloutSnapshot) bool {
+	if a == nil || b == nil {
+		return false
 	}
 
-	c.view.RenderSnapshot(snapshot)
+	x, y := *a, *b
+	x.SnapshotTime, y.SnapshotTime = time.Time{}, time.Time{}
 
-	return RolloutResult{
-		Done:   snapshot.Status.IsDone(),
-		Failed: snapshot.Status.IsFailed(),
-	}, nil
+	return reflect.DeepEqual(x, y)
 }
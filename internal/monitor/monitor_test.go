@@ -0,0 +1,202 @@
+package monitor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/simulator"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// TestControllerRunScenarios drives full simulated rollouts through Controller.Run with --until-complete.
+func TestControllerRunScenarios(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		scenario         simulator.Scenario
+		progressDeadline time.Duration
+		timeout          time.Duration
+		wantErr          error  // Expected error, nil for success
+		wantClass        error  // Class the exit code is derived from, nil for success
+		wantProblem      string // Container problem reason some snapshot must report, empty for none
+	}{
+		{
+			name:             "success",
+			scenario:         simulator.ScenarioSuccess,
+			progressDeadline: time.Minute,
+			timeout:          10 * time.Second,
+		},
+		{
+			name:             "progress deadline exceeded",
+			scenario:         simulator.ScenarioDeadline,
+			progressDeadline: 300 * time.Millisecond,
+			timeout:          10 * time.Second,
+			wantErr:          monitor.ErrProgressDeadlineExceeded,
+			wantClass:        monitor.ErrRolloutFailed,
+		},
+		{
+			name:             "crash loop",
+			scenario:         simulator.ScenarioCrashLoop,
+			progressDeadline: time.Minute,
+			timeout:          2 * time.Second,
+			wantErr:          monitor.ErrWatchTimeout,
+			wantClass:        monitor.ErrWatchTimeout,
+			wantProblem:      "CrashLoopBackOff",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := simulator.DefaultOptions()
+			opts.Scenario = tt.scenario
+			opts.Step = 10 * time.Millisecond
+			opts.ProgressDeadline = tt.progressDeadline
+
+			config := monitor.DefaultConfig()
+			config.Output = monitor.OutputJSONL
+			config.UntilComplete = true
+			config.Timeout = tt.timeout
+			config.DebounceMilliseconds = 10
+			config.Out = io.Discard
+
+			var recording bytes.Buffer
+
+			config.Record = &recording
+
+			err := runSimulated(t, opts, config)
+
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Run() error = %v, want nil", err)
+				}
+			} else {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+				}
+
+				if !errors.Is(err, tt.wantClass) {
+					t.Errorf("Run() error = %v, want class %v", err, tt.wantClass)
+				}
+			}
+
+			snapshots := recordedSnapshots(t, &recording)
+			if len(snapshots) == 0 {
+				t.Fatal("no snapshots recorded")
+			}
+
+			last := snapshots[len(snapshots)-1]
+			if tt.wantErr == nil && last.Status != "complete" {
+				t.Errorf("last snapshot status = %s, want complete", last.Status)
+			}
+
+			if tt.wantProblem != "" && !slices.ContainsFunc(snapshots, func(s recordedSnapshot) bool {
+				return slices.ContainsFunc(s.Problems, func(p recordedProblem) bool {
+					return p.Reason == tt.wantProblem
+				})
+			}) {
+				t.Errorf("no snapshot reported a %s problem", tt.wantProblem)
+			}
+		})
+	}
+}
+
+// runSimulated runs the simulated cluster and monitors its deployment until Run returns.
+func runSimulated(t *testing.T, opts simulator.Options, config monitor.Config) error {
+	t.Helper()
+
+	cluster, err := simulator.New(opts)
+	if err != nil {
+		t.Fatalf("simulator.New() error = %v", err)
+	}
+
+	spec := monitor.TargetSpec{Targets: []monitor.Target{{Kind: types.KindDeployment, Name: opts.Name}}}
+
+	controller, err := monitor.NewWithConfig(cluster.Source(), spec, config)
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	simulated := make(chan error, 1)
+
+	go func() { simulated <- cluster.Run(ctx) }()
+
+	watchCtx, cancelWatch := monitor.WithWatchTimeout(ctx, config.Timeout)
+	err = controller.Run(watchCtx)
+
+	cancelWatch()
+	cancel()
+
+	simErr := <-simulated
+	if simErr != nil {
+		t.Fatalf("simulation failed: %v", simErr)
+	}
+
+	return err
+}
+
+// recordedSnapshot is the part of a recorded snapshot the tests check.
+type recordedSnapshot struct {
+	Status   string               `json:"status"`
+	New      recordedReplicaState `json:"new"`
+	Old      recordedReplicaState `json:"old"`
+	Progress struct {
+		New float64 `json:"new"`
+	} `json:"progress"`
+	Problems []recordedProblem `json:"problems"`
+}
+
+type recordedReplicaState struct {
+	Current   int32 `json:"current"`
+	Ready     int32 `json:"ready"`
+	Available int32 `json:"available"`
+}
+
+type recordedProblem struct {
+	Reason string `json:"reason"`
+}
+
+// recordedSnapshots decodes the snapshots of a session recording, skipping its header.
+func recordedSnapshots(t *testing.T, r io.Reader) []recordedSnapshot {
+	t.Helper()
+
+	decoder := json.NewDecoder(r)
+
+	var header json.RawMessage
+
+	err := decoder.Decode(&header)
+	if err != nil {
+		t.Fatalf("failed to read session header: %v", err)
+	}
+
+	var snapshots []recordedSnapshot
+
+	for {
+		var record struct {
+			Snapshot *recordedSnapshot `json:"snapshot"`
+		}
+
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			return snapshots
+		}
+
+		if err != nil {
+			t.Fatalf("failed to read session record: %v", err)
+		}
+
+		if record.Snapshot != nil {
+			snapshots = append(snapshots, *record.Snapshot)
+		}
+	}
+}
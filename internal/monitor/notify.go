@@ -0,0 +1,397 @@
+package monitor
+
+// This file contains webhook notifications on rollout state transitions (--notify-webhook).
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultStallSeconds is how long a progressing rollout may go without progress before it is reported as stalled
+	DefaultStallSeconds = 120
+	// notifyTimeout bounds a single webhook request
+	notifyTimeout = 10 * time.Second
+	// notifyFlushTimeout bounds how long exiting waits for queued notifications
+	notifyFlushTimeout = 15 * time.Second
+	// DefaultNotifyContentType is the Content-Type of payloads rendered by DefaultNotifyTemplate
+	DefaultNotifyContentType = "application/json"
+	// notifyQueueSize is how many notifications may wait for delivery before new ones are dropped
+	notifyQueueSize = 32
+	// maxNotifyEvents limits the event clusters included in a notification
+	maxNotifyEvents = 3
+)
+
+// NotifyEvent is a rollout state transition reported to the webhook.
+type NotifyEvent string
+
+const (
+	// NotifyStarted is sent when a new rollout begins (the new ReplicaSet or update revision changes)
+	NotifyStarted NotifyEvent = "started"
+	// NotifyWarning is sent on the first warning event cluster of a rollout
+	NotifyWarning NotifyEvent = "warning"
+	// NotifyStalled is sent when a rollout is halted or makes no progress for the stall timeout
+	NotifyStalled NotifyEvent = "stalled"
+	// NotifyComplete is sent when a rollout completes
+	NotifyComplete NotifyEvent = "complete"
+	// NotifyDeadlineExceeded is sent when a rollout exceeds its progress deadline
+	NotifyDeadlineExceeded NotifyEvent = "deadline_exceeded"
+)
+
+// DefaultNotifyTemplate renders a JSON payload with a chat-friendly "text" field (Slack, Mattermost,
+// Google Chat and others accept it as is) and the transition details.
+// Each event stays on one line: {{- " " }} trims the template's line break and writes a space.
+const DefaultNotifyTemplate = `{
+  "text": {{ json .Text }},
+  "event": {{ json .Event }},
+  "kind": {{ json .Kind }},
+  "name": {{ json .Name }},
+  "revision": {{ json .Revision }},
+  "status": {{ json .Status }},
+  "statusMessage": {{ json .StatusMessage }},
+  "durationSeconds": {{ .Duration.Seconds }},
+  "replicas": {"desired": {{ .Desired }}, "available": {{ .Available }}},
+  "events": [{{ range $i, $e := .Events }}{{ if $i }}, {{ end }}{"type": {{ json $e.Type }},
+    {{- " " }}"reason": {{ json $e.Reason }}, "message": {{ json $e.Message }},
+    {{- " " }}"count": {{ $e.ExemplarCount }}}{{ end }}]
+}
+`
+
+// Notification is the data the webhook payload template is executed with.
+type Notification struct {
+	Event         NotifyEvent
+	Time          time.Time
+	Kind          string // Lowercase workload kind, e.g. "deployment"
+	Name          string
+	Revision      string // New ReplicaSet or update ControllerRevision
+	Status        string // Same values as the JSON Lines "status" field
+	StatusMessage string
+	Duration      time.Duration // Rollout duration so far, or until completion
+	Desired       int32
+	Available     int32                // Available replicas of the new revision
+	Events        []types.EventCluster // Top event clusters, warnings first
+	Text          string               // One-line human-readable summary
+}
+
+// ParseNotifyTemplate parses a webhook payload template. The "json" function encodes a value as JSON.
+func ParseNotifyTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("notify").Funcs(template.FuncMap{"json": notifyJSON}).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse notification template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// notifyContentType returns the Content-Type of webhook requests: the configured one, JSON for
+// the default template, or none, as a custom template may render any format.
+func notifyContentType(config Config) string {
+	if config.NotifyContentType == "" && config.NotifyTemplate == "" {
+		return DefaultNotifyContentType
+	}
+
+	return config.NotifyContentType
+}
+
+// notifyJSON encodes a template value as JSON.
+func notifyJSON(v any) (string, error) {
+	raw, err := json.Marshal(v)
+
+	return string(raw), err
+}
+
+// notifyState tracks which transitions of a workload's current rollout were already reported.
+type notifyState struct {
+	rollout string               // NewRSName of the rollout the flags below belong to
+	sent    map[NotifyEvent]bool // Transitions reported for this rollout
+}
+
+// transitions returns the transitions a snapshot makes, marking them as reported.
+// The first snapshot of a watch only reports a rollout still in progress, so attaching
+// to an already finished (or settled) rollout stays quiet. A stall is reported again after progress resumes.
+// Rollouts without progress yet, such as StatefulSets and DaemonSets before their first updated pod
+// is ready, stall once the stall timeout passes since the rollout started.
+func (n *notifyState) transitions(s *types.RolloutSnapshot, stallAfter time.Duration) []NotifyEvent {
+	var events []NotifyEvent
+
+	if s.NewRSName != n.rollout {
+		first := n.rollout == ""
+		n.rollout = s.NewRSName
+		n.sent = make(map[NotifyEvent]bool)
+
+		if first && (s.Status.IsDone() || settled(s)) {
+			n.sent[NotifyWarning] = true
+			n.sent[NotifyComplete] = true
+			n.sent[NotifyDeadlineExceeded] = true
+		} else {
+			events = append(events, NotifyStarted)
+		}
+	}
+
+	lastProgress := s.StartTime
+	if s.ProgressUpdateTime != nil {
+		lastProgress = *s.ProgressUpdateTime
+	}
+
+	stalled := s.Status.IsHalted() ||
+		s.Status == types.StatusProgressing && s.SnapshotTime.Sub(lastProgress) >= stallAfter
+
+	candidates := map[NotifyEvent]bool{
+		NotifyWarning:          hasWarningCluster(s),
+		NotifyStalled:          stalled,
+		NotifyComplete:         s.Status == types.StatusComplete,
+		NotifyDeadlineExceeded: s.Status == types.StatusDeadlineExceeded,
+	}
+
+	for _, event := range []NotifyEvent{NotifyWarning, NotifyStalled, NotifyComplete, NotifyDeadlineExceeded} {
+		if candidates[event] && !n.sent[event] {
+			events = append(events, event)
+		}
+
+		n.sent[event] = n.sent[event] || candidates[event]
+	}
+
+	if !stalled {
+		n.sent[NotifyStalled] = false
+	}
+
+	return events
+}
+
+// settled reports whether every pod already runs the new revision, as while a rollout that
+// just finished waits for the controller to observe the next generation.
+func settled(s *types.RolloutSnapshot) bool {
+	return s.NewRS.Available >= s.UpdateTarget() && s.OldRS.Current == 0
+}
+
+// hasWarningCluster reports whether a snapshot carries a warning event cluster.
+func hasWarningCluster(s *types.RolloutSnapshot) bool {
+	for _, c := range s.Events.Clusters {
+		if c.Type == corev1.EventTypeWarning {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newNotification describes a transition of a snapshot for the payload template.
+func newNotification(event NotifyEvent, s *types.RolloutSnapshot) Notification {
+	end := s.SnapshotTime
+	if s.Status.IsDone() && s.ProgressUpdateTime != nil {
+		end = *s.ProgressUpdateTime
+	}
+
+	n := Notification{
+		Event:         event,
+		Time:          s.SnapshotTime,
+		Kind:          strings.ToLower(string(s.Kind)),
+		Name:          s.WorkloadName,
+		Revision:      s.NewRSName,
+		Status:        jsonStatus(s.Status),
+		StatusMessage: s.StatusMessage,
+		Duration:      end.Sub(s.StartTime).Round(time.Second),
+		Desired:       s.UpdateTarget(),
+		Available:     s.NewRS.Available,
+		Events:        topEventClusters(s.Events.Clusters, maxNotifyEvents),
+	}
+
+	n.Text = notificationText(n)
+
+	return n
+}
+
+// notificationText summarizes a notification in one line, e.g.
+// "✓ deployment/web rollout complete in 2m3s (web-5d8f, 4/4 available)".
+func notificationText(n Notification) string {
+	workload := n.Kind + "/" + n.Name
+	progress := fmt.Sprintf("(%s, %d/%d available)", n.Revision, n.Available, n.Desired)
+	duration := types.FormatDuration(n.Duration)
+
+	var text string
+
+	switch n.Event {
+	case NotifyStarted:
+		text = fmt.Sprintf("▶ %s rollout started %s", workload, progress)
+	case NotifyWarning:
+		text = fmt.Sprintf("⚠ %s rollout reports warnings after %s %s", workload, duration, progress)
+	case NotifyStalled:
+		text = fmt.Sprintf("⏸ %s rollout stalled after %s %s", workload, duration, progress)
+	case NotifyComplete:
+		text = fmt.Sprintf("✓ %s rollout complete in %s %s", workload, duration, progress)
+	case NotifyDeadlineExceeded:
+		text = fmt.Sprintf("✗ %s rollout exceeded its progress deadline after %s %s", workload, duration, progress)
+	}
+
+	if n.StatusMessage != "" {
+		text += ": " + n.StatusMessage
+	} else if len(n.Events) > 0 && n.Event != NotifyStarted && n.Event != NotifyComplete {
+		text += ": " + n.Events[0].Reason + ": " + n.Events[0].Message
+	}
+
+	return text
+}
+
+// webhookNotifier posts rendered notifications to a webhook in order, off the monitoring loop.
+type webhookNotifier struct {
+	url         string
+	template    *template.Template
+	contentType string // Content-Type header of requests, empty sends none
+	client      *http.Client
+	queue       chan []byte
+	done        chan struct{}
+	ctx         context.Context    // Cancelled to abandon undelivered notifications
+	cancel      context.CancelFunc // Cancels ctx
+
+	mu        sync.Mutex
+	failures  int   // Notifications that could not be rendered or delivered
+	lastError error // Why the last of them failed
+	dropped   int   // Notifications dropped because the queue was full
+	abandoned int   // Notifications not delivered before exiting
+}
+
+// newWebhookNotifier creates a notifier and starts its delivery loop.
+func newWebhookNotifier(url string, tmpl *template.Template, contentType string) *webhookNotifier {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	n := &webhookNotifier{
+		url:         url,
+		template:    tmpl,
+		contentType: contentType,
+		client:      &http.Client{Timeout: notifyTimeout},
+		queue:       make(chan []byte, notifyQueueSize),
+		done:        make(chan struct{}),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	go n.deliver()
+
+	return n
+}
+
+// notify renders a notification and queues it for delivery. Never blocks the monitoring loop.
+func (n *webhookNotifier) notify(notification Notification) {
+	var payload bytes.Buffer
+
+	err := n.template.Execute(&payload, notification)
+	if err != nil {
+		n.fail(fmt.Errorf("failed to render %s notification for %s/%s: %w",
+			notification.Event, notification.Kind, notification.Name, err))
+
+		return
+	}
+
+	select {
+	case n.queue <- payload.Bytes():
+	default:
+		n.mu.Lock()
+		n.dropped++
+		n.mu.Unlock()
+	}
+}
+
+// fail records a notification that could not be rendered or delivered.
+func (n *webhookNotifier) fail(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.failures++
+	n.lastError = err
+}
+
+// deliver posts queued payloads until the queue is closed.
+func (n *webhookNotifier) deliver() {
+	defer close(n.done)
+
+	for payload := range n.queue {
+		err := n.post(payload)
+
+		switch {
+		case err == nil:
+		case n.ctx.Err() != nil:
+			n.mu.Lock()
+			n.abandoned++
+			n.mu.Unlock()
+		default:
+			n.fail(err)
+		}
+	}
+}
+
+// post sends one payload to the webhook.
+func (n *webhookNotifier) post(payload []byte) error {
+	ctx, cancel := context.WithTimeout(n.ctx, notifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	if n.contentType != "" {
+		req.Header.Set("Content-Type", n.contentType)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // response body is not used
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// close waits for queued notifications to be delivered, at most notifyFlushTimeout and only
+// until ctx is cancelled (Ctrl+C), so an unresponsive webhook cannot hold up exiting.
+// Returns a summary of failed, dropped and abandoned notifications, if any.
+func (n *webhookNotifier) close(ctx context.Context) error {
+	close(n.queue)
+
+	timer := time.NewTimer(notifyFlushTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-n.done:
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	// Undelivered notifications fail at once, so the delivery loop ends promptly
+	n.cancel()
+	<-n.done
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var errs []error
+
+	if n.failures > 0 {
+		errs = append(errs, fmt.Errorf("%d webhook notifications failed, last error: %w", n.failures, n.lastError))
+	}
+
+	if n.dropped > 0 {
+		errs = append(errs, fmt.Errorf("%d webhook notifications dropped, the webhook is too slow", n.dropped))
+	}
+
+	if n.abandoned > 0 {
+		errs = append(errs, fmt.Errorf("%d webhook notifications not delivered before exiting", n.abandoned))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,464 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// notifyPayload is the part of the default template's payload the tests check.
+type notifyPayload struct {
+	Text     string `json:"text"`
+	Event    string `json:"event"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Revision string `json:"revision"`
+	Replicas struct {
+		Desired int32 `json:"desired"`
+	} `json:"replicas"`
+}
+
+// TestWebhookNotifications feeds snapshots through the transition tracking and the webhook
+// notifier, and checks the payloads a local HTTP server receives.
+func TestWebhookNotifications(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		when := start.Add(d)
+
+		return &when
+	}
+
+	warning := types.EventSummary{Clusters: []types.EventCluster{{
+		Source: types.PodEventSource, Type: corev1.EventTypeWarning, Reason: "BackOff",
+		Message: "Back-off restarting failed container", ExemplarCount: 3,
+	}}}
+
+	// snapshot describes a rollout of web-2 at elapsed time d with the given available replicas
+	snapshot := func(
+		kind types.WorkloadKind,
+		status types.RolloutStatus,
+		d time.Duration,
+		available int32,
+	) types.RolloutSnapshot {
+		return types.RolloutSnapshot{
+			Kind:         kind,
+			WorkloadName: "web",
+			NewRSName:    "web-2",
+			Desired:      4,
+			NewRS:        types.ReplicaSetState{Current: 4, Ready: available, Available: available},
+			OldRS:        types.ReplicaSetState{Current: 4 - available, Available: 4 - available},
+			StartTime:    start,
+			SnapshotTime: start.Add(d),
+			Status:       status,
+		}
+	}
+
+	progressed := snapshot(types.KindDeployment, types.StatusProgressing, 30*time.Second, 2)
+	progressed.ProgressUpdateTime = at(20 * time.Second)
+
+	withWarning := snapshot(types.KindDeployment, types.StatusProgressing, 40*time.Second, 2)
+	withWarning.ProgressUpdateTime = at(20 * time.Second)
+	withWarning.Events = warning
+
+	complete := snapshot(types.KindDeployment, types.StatusComplete, time.Minute, 4)
+	complete.ProgressUpdateTime = at(50 * time.Second)
+
+	stuck := snapshot(types.KindDeployment, types.StatusProgressing, 3*time.Minute, 2)
+	stuck.ProgressUpdateTime = at(20 * time.Second)
+
+	tests := []struct {
+		name      string
+		snapshots []types.RolloutSnapshot
+		want      []NotifyEvent
+	}{
+		{
+			name: "rollout completes",
+			snapshots: []types.RolloutSnapshot{
+				snapshot(types.KindDeployment, types.StatusProgressing, 0, 0),
+				progressed, withWarning, withWarning, complete,
+			},
+			want: []NotifyEvent{NotifyStarted, NotifyWarning, NotifyComplete},
+		},
+		{
+			name:      "attaching to a finished rollout stays quiet",
+			snapshots: []types.RolloutSnapshot{complete, complete},
+		},
+		{
+			name: "deadline exceeded",
+			snapshots: []types.RolloutSnapshot{
+				progressed,
+				snapshot(types.KindDeployment, types.StatusDeadlineExceeded, 10*time.Minute, 2),
+			},
+			want: []NotifyEvent{NotifyStarted, NotifyDeadlineExceeded},
+		},
+		{
+			name:      "stall is reported again after progress resumes",
+			snapshots: []types.RolloutSnapshot{progressed, stuck, stuck, progressed, stuck},
+			want:      []NotifyEvent{NotifyStarted, NotifyStalled, NotifyStalled},
+		},
+		{
+			name: "statefulset without ready pods stalls",
+			snapshots: []types.RolloutSnapshot{
+				snapshot(types.KindStatefulSet, types.StatusProgressing, time.Minute, 0),
+				snapshot(types.KindStatefulSet, types.StatusProgressing, 3*time.Minute, 0),
+			},
+			want: []NotifyEvent{NotifyStarted, NotifyStalled},
+		},
+		{
+			name: "paused rollout stalls",
+			snapshots: []types.RolloutSnapshot{
+				progressed,
+				snapshot(types.KindDeployment, types.StatusPaused, 35*time.Second, 2),
+			},
+			want: []NotifyEvent{NotifyStarted, NotifyStalled},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				payloads []notifyPayload
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				var payload notifyPayload
+
+				err := json.Unmarshal(body, &payload)
+				if err != nil {
+					t.Errorf("payload is not valid JSON: %v\n%s", err, body)
+				}
+
+				if r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", r.Header.Get("Content-Type"))
+				}
+
+				mu.Lock()
+				payloads = append(payloads, payload)
+				mu.Unlock()
+			}))
+			defer server.Close()
+
+			tmpl, err := ParseNotifyTemplate(DefaultNotifyTemplate)
+			if err != nil {
+				t.Fatalf("ParseNotifyTemplate() error = %v", err)
+			}
+
+			notifier := newWebhookNotifier(server.URL, tmpl, DefaultNotifyContentType)
+
+			var state notifyState
+
+			for _, s := range tt.snapshots {
+				for _, event := range state.transitions(&s, DefaultStallSeconds*time.Second) {
+					notifier.notify(newNotification(event, &s))
+				}
+			}
+
+			err = notifier.close(t.Context())
+			if err != nil {
+				t.Fatalf("close() error = %v", err)
+			}
+
+			var got []NotifyEvent
+			for _, p := range payloads {
+				got = append(got, NotifyEvent(p.Event))
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("events = %v, want %v", got, tt.want)
+			}
+
+			for _, p := range payloads {
+				kind := "deployment"
+				if tt.snapshots[0].Kind == types.KindStatefulSet {
+					kind = "statefulset"
+				}
+
+				if p.Kind != kind || p.Name != "web" || p.Revision != "web-2" ||
+					p.Replicas.Desired != 4 || p.Text == "" {
+					t.Errorf("%s payload = %+v, want %s/web revision web-2 of 4 replicas with text", p.Event, p, kind)
+				}
+			}
+		})
+	}
+}
+
+// TestWebhookNotificationPayload checks every field of a default template payload.
+func TestWebhookNotificationPayload(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	done := start.Add(2*time.Minute + 3*time.Second)
+
+	s := &types.RolloutSnapshot{
+		Kind:               types.KindDeployment,
+		WorkloadName:       "web",
+		NewRSName:          "web-5d8f",
+		Desired:            4,
+		NewRS:              types.ReplicaSetState{Current: 4, Ready: 4, Available: 4},
+		StartTime:          start,
+		SnapshotTime:       done.Add(time.Second),
+		ProgressUpdateTime: &done,
+		Status:             types.StatusComplete,
+		Events: types.EventSummary{Clusters: []types.EventCluster{
+			{Type: corev1.EventTypeNormal, Reason: "Pulled", Message: "pulled", ExemplarCount: 4},
+			{Type: corev1.EventTypeWarning, Reason: "Unhealthy", Message: "probe", ExemplarCount: 2},
+		}},
+	}
+
+	received := make(chan []byte, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	tmpl, err := ParseNotifyTemplate(DefaultNotifyTemplate)
+	if err != nil {
+		t.Fatalf("ParseNotifyTemplate() error = %v", err)
+	}
+
+	notifier := newWebhookNotifier(server.URL, tmpl, DefaultNotifyContentType)
+	notifier.notify(newNotification(NotifyComplete, s))
+
+	err = notifier.close(t.Context())
+	if err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+
+	var got map[string]any
+
+	err = json.Unmarshal(<-received, &got)
+	if err != nil {
+		t.Fatalf("payload is not valid JSON: %v", err)
+	}
+
+	want := map[string]any{
+		"text":            "✓ deployment/web rollout complete in 2m3s (web-5d8f, 4/4 available)",
+		"event":           "complete",
+		"kind":            "deployment",
+		"name":            "web",
+		"revision":        "web-5d8f",
+		"status":          "complete",
+		"statusMessage":   "",
+		"durationSeconds": 123.0,
+		"replicas":        map[string]any{"desired": 4.0, "available": 4.0},
+		"events": []any{
+			map[string]any{"type": "Warning", "reason": "Unhealthy", "message": "probe", "count": 2.0},
+			map[string]any{"type": "Normal", "reason": "Pulled", "message": "pulled", "count": 4.0},
+		},
+	}
+
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("payload = %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+// TestWebhookNotifierReportsFailures checks that rejected notifications are reported on close.
+func TestWebhookNotifierReportsFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tmpl, err := ParseNotifyTemplate(DefaultNotifyTemplate)
+	if err != nil {
+		t.Fatalf("ParseNotifyTemplate() error = %v", err)
+	}
+
+	notifier := newWebhookNotifier(server.URL, tmpl, DefaultNotifyContentType)
+	notifier.notify(newNotification(NotifyStarted, &types.RolloutSnapshot{
+		Kind:         types.KindDeployment,
+		WorkloadName: "web",
+	}))
+
+	err = notifier.close(t.Context())
+	if err == nil {
+		t.Fatal("close() error = nil, want delivery failure")
+	}
+}
+
+// TestNotifyTransitions checks which transitions snapshot sequences report, beyond the delivery cases above.
+func TestNotifyTransitions(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// snapshot describes rollout rs at elapsed time d, last progressing at elapsed time progressed
+	snapshot := func(rs string, status types.RolloutStatus, d, progressed time.Duration) types.RolloutSnapshot {
+		progressTime := start.Add(progressed)
+
+		return types.RolloutSnapshot{
+			Kind:               types.KindDeployment,
+			WorkloadName:       "web",
+			NewRSName:          rs,
+			Desired:            4,
+			NewRS:              types.ReplicaSetState{Current: 4, Ready: 2, Available: 2},
+			OldRS:              types.ReplicaSetState{Current: 2, Available: 2},
+			StartTime:          start,
+			SnapshotTime:       start.Add(d),
+			ProgressUpdateTime: &progressTime,
+			Status:             status,
+		}
+	}
+
+	withWarning := func(s types.RolloutSnapshot) types.RolloutSnapshot {
+		s.Events = types.EventSummary{Clusters: []types.EventCluster{{Type: corev1.EventTypeWarning, Reason: "BackOff"}}}
+
+		return s
+	}
+
+	settledFirst := snapshot("web-2", types.StatusProgressing, time.Minute, 50*time.Second)
+	settledFirst.NewRS = types.ReplicaSetState{Current: 4, Ready: 4, Available: 4}
+	settledFirst.OldRS = types.ReplicaSetState{}
+
+	tests := []struct {
+		name      string
+		snapshots []types.RolloutSnapshot
+		want      [][]NotifyEvent // Transitions of each snapshot
+	}{
+		{
+			name: "next rollout is reported from the start",
+			snapshots: []types.RolloutSnapshot{
+				snapshot("web-2", types.StatusComplete, time.Minute, 50*time.Second),
+				snapshot("web-3", types.StatusProgressing, 90*time.Second, time.Minute),
+				snapshot("web-3", types.StatusComplete, 3*time.Minute, 3*time.Minute),
+			},
+			want: [][]NotifyEvent{nil, {NotifyStarted}, {NotifyComplete}},
+		},
+		{
+			name: "warnings are reported once per rollout",
+			snapshots: []types.RolloutSnapshot{
+				withWarning(snapshot("web-2", types.StatusProgressing, 0, 0)),
+				withWarning(snapshot("web-2", types.StatusProgressing, 10*time.Second, 0)),
+				withWarning(snapshot("web-3", types.StatusProgressing, 20*time.Second, 20*time.Second)),
+			},
+			want: [][]NotifyEvent{
+				{NotifyStarted, NotifyWarning}, nil, {NotifyStarted, NotifyWarning},
+			},
+		},
+		{
+			name: "replica failure stalls at once",
+			snapshots: []types.RolloutSnapshot{
+				snapshot("web-2", types.StatusProgressing, 0, 0),
+				snapshot("web-2", types.StatusReplicaFailure, 5*time.Second, 0),
+			},
+			want: [][]NotifyEvent{{NotifyStarted}, {NotifyStalled}},
+		},
+		{
+			name: "stall timeout counts from the last progress",
+			snapshots: []types.RolloutSnapshot{
+				snapshot("web-2", types.StatusProgressing, 0, 0),
+				snapshot("web-2", types.StatusProgressing, 3*time.Minute, 2*time.Minute),
+				snapshot("web-2", types.StatusProgressing, 5*time.Minute, 2*time.Minute),
+			},
+			want: [][]NotifyEvent{{NotifyStarted}, nil, {NotifyStalled}},
+		},
+		{
+			name:      "attaching after pods settled stays quiet",
+			snapshots: []types.RolloutSnapshot{settledFirst, settledFirst},
+			want:      [][]NotifyEvent{nil, nil},
+		},
+		{
+			name: "superseded rollout is neither complete nor failed",
+			snapshots: []types.RolloutSnapshot{
+				snapshot("web-2", types.StatusProgressing, 0, 0),
+				snapshot("web-2", types.StatusSuperseded, 10*time.Second, 0),
+			},
+			want: [][]NotifyEvent{{NotifyStarted}, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var state notifyState
+
+			for i, s := range tt.snapshots {
+				got := state.transitions(&s, DefaultStallSeconds*time.Second)
+				if !slices.Equal(got, tt.want[i]) {
+					t.Errorf("snapshot %d transitions = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestNotifyContentType checks that only the default template implies a JSON Content-Type.
+func TestNotifyContentType(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+		want   string
+	}{
+		{name: "default template", want: DefaultNotifyContentType},
+		{name: "custom template", config: Config{NotifyTemplate: "{{ .Text }}"}},
+		{
+			name:   "custom template with content type",
+			config: Config{NotifyTemplate: "{{ .Text }}", NotifyContentType: "text/plain"},
+			want:   "text/plain",
+		},
+		{name: "default template with content type", config: Config{NotifyContentType: "text/json"}, want: "text/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := notifyContentType(tt.config); got != tt.want {
+				t.Errorf("notifyContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestWebhookNotifierCloseCancelled checks that a cancelled context abandons notifications
+// an unresponsive webhook has not accepted, instead of waiting for the flush timeout.
+func TestWebhookNotifierCloseCancelled(t *testing.T) {
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	tmpl, err := ParseNotifyTemplate("{{ .Text }}")
+	if err != nil {
+		t.Fatalf("ParseNotifyTemplate() error = %v", err)
+	}
+
+	notifier := newWebhookNotifier(server.URL, tmpl, "")
+	for range 2 {
+		notifier.notify(newNotification(NotifyStarted, &types.RolloutSnapshot{
+			Kind:         types.KindDeployment,
+			WorkloadName: "web",
+		}))
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	begin := time.Now()
+
+	err = notifier.close(ctx)
+	if err == nil || !strings.Contains(err.Error(), "2 webhook notifications not delivered") {
+		t.Errorf("close() error = %v, want 2 notifications not delivered", err)
+	}
+
+	if elapsed := time.Since(begin); elapsed > notifyTimeout/2 {
+		t.Errorf("close() took %s, want it to return promptly", elapsed)
+	}
+}
@@ -0,0 +1,217 @@
+package monitor
+
+// This file contains pod readiness helpers shared by workload snapshot builders.
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// maxPodEvents limits the events kept per pod for the drill-down panel.
+const maxPodEvents = 10
+
+// podBreakdown groups a workload's pods by revision for snapshot construction.
+type podBreakdown struct {
+	infos    []types.PodInfo       // Per-pod states in input order
+	newState types.ReplicaSetState // Counts for pods running the updated revision
+	oldState types.ReplicaSetState // Counts for pods running any older revision
+	newPods  []*corev1.Pod         // Pods running the updated revision
+}
+
+// breakdownPods classifies pods as new or old using isNew and counts their lifecycle stages.
+// Terminating pods are skipped, matching how controllers count replicas.
+func breakdownPods(
+	pods []*corev1.Pod,
+	isNew func(*corev1.Pod) bool,
+	minReadySeconds int32,
+	now time.Time,
+) podBreakdown {
+	var b podBreakdown
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		state := podState(pod, minReadySeconds, now)
+		newPod := isNew(pod)
+
+		counts := &b.oldState
+		if newPod {
+			counts = &b.newState
+			b.newPods = append(b.newPods, pod)
+		}
+
+		counts.Current++
+
+		if state >= types.PodReady {
+			counts.Ready++
+		}
+
+		if state == types.PodAvailable {
+			counts.Available++
+		}
+
+		b.infos = append(b.infos, newPodInfo(pod, newPod, state))
+	}
+
+	return b
+}
+
+// podState returns the most advanced lifecycle stage the pod has reached.
+func podState(pod *corev1.Pod, minReadySeconds int32, now time.Time) types.PodState {
+	readySince := podReadySince(pod)
+	if readySince == nil {
+		return types.PodCurrent
+	}
+
+	if now.Sub(*readySince) < time.Duration(minReadySeconds)*time.Second {
+		return types.PodReady
+	}
+
+	return types.PodAvailable
+}
+
+// podReadySince returns when the pod became ready, or nil if it is not ready.
+func podReadySince(pod *corev1.Pod) *time.Time {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return &c.LastTransitionTime.Time
+		}
+	}
+
+	return nil
+}
+
+// latestReadyTime returns the most recent Ready transition among pods, or nil if none are ready.
+// Used as the completion time for workloads without a Progressing condition.
+func latestReadyTime(pods []*corev1.Pod) *time.Time {
+	var latest *time.Time
+
+	for _, pod := range pods {
+		if t := podReadySince(pod); t != nil && (latest == nil || t.After(*latest)) {
+			latest = t
+		}
+	}
+
+	return latest
+}
+
+// newPodInfo describes a pod for the pods grid and its drill-down panel. Events are attached separately.
+func newPodInfo(pod *corev1.Pod, newPod bool, state types.PodState) types.PodInfo {
+	info := types.PodInfo{
+		Name:    pod.Name,
+		Node:    pod.Spec.NodeName,
+		New:     newPod,
+		State:   state,
+		IP:      pod.Status.PodIP,
+		Phase:   string(pod.Status.Phase),
+		Created: pod.CreationTimestamp.Time,
+	}
+
+	for _, c := range pod.Spec.InitContainers {
+		info.Containers = append(info.Containers, newContainerInfo(c.Name, true, pod.Status.InitContainerStatuses))
+	}
+
+	for _, c := range pod.Spec.Containers {
+		info.Containers = append(info.Containers, newContainerInfo(c.Name, false, pod.Status.ContainerStatuses))
+	}
+
+	for _, gate := range pod.Spec.ReadinessGates {
+		ready := slices.ContainsFunc(pod.Status.Conditions, func(c corev1.PodCondition) bool {
+			return c.Type == gate.ConditionType && c.Status == corev1.ConditionTrue
+		})
+		info.ReadinessGates = append(info.ReadinessGates, types.ReadinessGate{
+			Condition: string(gate.ConditionType),
+			Ready:     ready,
+		})
+	}
+
+	return info
+}
+
+// newContainerInfo describes a container from its status, if the kubelet reported one yet.
+func newContainerInfo(name string, init bool, statuses []corev1.ContainerStatus) types.ContainerInfo {
+	info := types.ContainerInfo{Name: name, Init: init}
+
+	i := slices.IndexFunc(statuses, func(s corev1.ContainerStatus) bool { return s.Name == name })
+	if i < 0 {
+		return info
+	}
+
+	status := statuses[i]
+	info.Ready = status.Ready
+	info.Restarts = status.RestartCount
+
+	switch state := status.State; {
+	case state.Running != nil:
+		info.State = "Running"
+	case state.Waiting != nil:
+		info.State, info.Reason, info.Message = "Waiting", state.Waiting.Reason, state.Waiting.Message
+	case state.Terminated != nil:
+		termination := newContainerTermination(state.Terminated)
+		info.State, info.Reason, info.Message = "Terminated", termination.Reason, termination.Message
+		info.ExitCode = termination.ExitCode
+	}
+
+	return info
+}
+
+// attachPodEvents adds each pod's own events to its PodInfo, most recent first.
+func (c *Controller) attachPodEvents(ctx context.Context, infos []types.PodInfo, pods []*corev1.Pod) error {
+	byName := make(map[string]*corev1.Pod, len(pods))
+	for _, pod := range pods {
+		byName[pod.Name] = pod
+	}
+
+	for i := range infos {
+		pod, ok := byName[infos[i].Name]
+		if !ok {
+			continue
+		}
+
+		events, err := c.repo.GetEventsForObject(ctx, "Pod", pod)
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(events, func(a, b int) bool {
+			return getEventTime(&events[a]).After(getEventTime(&events[b]))
+		})
+
+		for _, event := range events[:min(len(events), maxPodEvents)] {
+			infos[i].Events = append(infos[i].Events, types.PodEvent{
+				Type:     event.Type,
+				Reason:   event.Reason,
+				Message:  sanitizeMessage(event.Message),
+				Count:    max(event.Count, 1),
+				LastSeen: getEventTime(&event),
+			})
+		}
+	}
+
+	return nil
+}
+
+// orderByRevision orders deployment pods for the grid: new pods first, then old ones,
+// each from the most advanced lifecycle stage down.
+func orderByRevision(infos []types.PodInfo) []types.PodInfo {
+	sort.SliceStable(infos, func(i, j int) bool {
+		if infos[i].New != infos[j].New {
+			return infos[i].New
+		}
+
+		if infos[i].State != infos[j].State {
+			return infos[i].State > infos[j].State
+		}
+
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
@@ -0,0 +1,125 @@
+package monitor
+
+// This file contains container-level failure diagnosis for new pods.
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// benignWaitingReasons are waiting reasons every container passes through on a healthy start.
+var benignWaitingReasons = []string{"ContainerCreating", "PodInitializing"}
+
+// problemKey groups identical container failures across pods.
+type problemKey struct {
+	container   string
+	reason      string
+	termination types.ContainerTermination
+}
+
+// DiagnoseContainers inspects container statuses of the given pods and reports failing containers.
+// Containers waiting for a non-benign reason (CrashLoopBackOff, ImagePullBackOff, ...), terminated
+// with a non-zero exit code, or restarted and not ready are reported. Identical failures of the
+// same container across pods are grouped. Terminating pods are skipped.
+func DiagnoseContainers(pods []*corev1.Pod) []types.ContainerProblem {
+	var (
+		keys     []problemKey
+		problems = make(map[problemKey]*types.ContainerProblem)
+	)
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		statuses := slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses)
+
+		for _, status := range statuses {
+			problem, ok := containerProblem(status)
+			if !ok {
+				continue
+			}
+
+			key := problemKey{container: problem.Container, reason: problem.Reason}
+			if problem.Termination != nil {
+				key.termination = *problem.Termination
+			}
+
+			existing, found := problems[key]
+			if !found {
+				existing = &problem
+				problems[key] = existing
+				keys = append(keys, key)
+			}
+
+			existing.Pods = append(existing.Pods, pod.Name)
+			existing.Restarts = max(existing.Restarts, status.RestartCount)
+		}
+	}
+
+	result := make([]types.ContainerProblem, 0, len(keys))
+	for _, key := range keys {
+		problem := problems[key]
+		slices.Sort(problem.Pods)
+		result = append(result, *problem)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if len(result[i].Pods) != len(result[j].Pods) {
+			return len(result[i].Pods) > len(result[j].Pods)
+		}
+
+		if result[i].Reason != result[j].Reason {
+			return result[i].Reason < result[j].Reason
+		}
+
+		return result[i].Container < result[j].Container
+	})
+
+	return result
+}
+
+// containerProblem builds a problem from a single container status.
+// Returns false if the container is healthy or still starting normally.
+func containerProblem(status corev1.ContainerStatus) (types.ContainerProblem, bool) {
+	problem := types.ContainerProblem{Container: status.Name}
+
+	if last := status.LastTerminationState.Terminated; last != nil {
+		problem.Termination = newContainerTermination(last)
+	}
+
+	state := status.State
+
+	switch {
+	case state.Waiting != nil && !slices.Contains(benignWaitingReasons, state.Waiting.Reason):
+		problem.Reason = state.Waiting.Reason
+		problem.Message = state.Waiting.Message
+	case state.Terminated != nil && state.Terminated.ExitCode != 0:
+		problem.Termination = newContainerTermination(state.Terminated)
+		problem.Reason = problem.Termination.Reason
+		problem.Message = state.Terminated.Message
+	case state.Running != nil && !status.Ready && status.RestartCount > 0 && problem.Termination != nil:
+		problem.Reason = problem.Termination.Reason
+	default:
+		return types.ContainerProblem{}, false
+	}
+
+	return problem, true
+}
+
+// newContainerTermination converts a terminated state, naming unlabeled failures "Error" like kubectl does.
+func newContainerTermination(terminated *corev1.ContainerStateTerminated) *types.ContainerTermination {
+	reason := terminated.Reason
+	if reason == "" {
+		reason = "Error"
+	}
+
+	return &types.ContainerTermination{
+		Reason:   reason,
+		ExitCode: terminated.ExitCode,
+		Message:  terminated.Message,
+	}
+}
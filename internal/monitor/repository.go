@@ -4,113 +4,473 @@ package monitor
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
 )
 
+// eventsByInvolvedObjectIndex indexes cached events by "Kind/Name" of their involved object.
+const eventsByInvolvedObjectIndex = "involvedObject"
+
+// syncRetryTimeout is how long Start keeps retrying failed lists and watches before
+// reporting the cluster as unavailable. Slow syncs without failures are waited for indefinitely.
+const syncRetryTimeout = 30 * time.Second
+
+// syncProbeInterval is how often Start checks the API server while waiting for the initial sync.
+const syncProbeInterval = 5 * time.Second
+
 // DeploymentRepository handles all Kubernetes API interactions.
 // Implements the repository pattern, isolating API concerns from business logic.
+// Objects are served from shared informer caches kept up to date by watches,
+// so reads never hit the API server after the initial sync.
 type DeploymentRepository struct {
-	clientset *kubernetes.Clientset
 	namespace string
+	clientset kubernetes.Interface // Direct API access for logs and writes (rollback), not served by informers
+	factory   informers.SharedInformerFactory
+
+	// Listers for workload kinds are only set once Start registers their informers
+	deployments         appslisters.DeploymentLister
+	replicaSets         appslisters.ReplicaSetLister
+	statefulSets        appslisters.StatefulSetLister
+	daemonSets          appslisters.DaemonSetLister
+	controllerRevisions appslisters.ControllerRevisionLister
+	pods                corelisters.PodLister
+	events              cache.Indexer
+
+	registered  map[cache.SharedIndexInformer]bool // Informers already hooked up, so Start can be called repeatedly
+	updates     chan struct{}                      // Signals that a cached object changed (coalesced, never blocks)
+	watchErrors chan error                         // Pending watch/list failure, consumed while waiting for initial sync
+	apiErrors   func(operation string)             // Failed API request hook (see SetAPIErrorHandler), may be nil
+	syncRetry   func(err error)                    // Initial sync retry hook (see SetSyncRetryHandler), may be nil
+}
+
+// NewDeploymentRepository creates a new repository instance.
+// Informers are registered but not started until Start is called.
+func NewDeploymentRepository(clientset kubernetes.Interface, namespace string) *DeploymentRepository {
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(namespace),
+		informers.WithTransform(stripManagedFields),
+	)
+
+	r := &DeploymentRepository{
+		namespace:   namespace,
+		clientset:   clientset,
+		factory:     factory,
+		pods:        factory.Core().V1().Pods().Lister(),
+		registered:  make(map[cache.SharedIndexInformer]bool),
+		updates:     make(chan struct{}, 1),
+		watchErrors: make(chan error, 1),
+	}
+
+	eventInformer := factory.Core().V1().Events().Informer()
+	_ = eventInformer.AddIndexers(cache.Indexers{eventsByInvolvedObjectIndex: indexEventByInvolvedObject})
+	r.events = eventInformer.GetIndexer()
+
+	r.register(factory.Core().V1().Pods().Informer(), eventInformer)
+
+	return r
+}
+
+// register hooks change notifications and watch error reporting into informers.
+// Informers that are already registered are skipped.
+func (r *DeploymentRepository) register(informers ...cache.SharedIndexInformer) {
+	for _, informer := range informers {
+		if r.registered[informer] {
+			continue
+		}
+
+		r.registered[informer] = true
+		_, _ = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    func(any) { r.notify() },
+			UpdateFunc: func(any, any) { r.notify() },
+			DeleteFunc: func(any) { r.notify() },
+		})
+		_ = informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) { r.reportWatchError(err) })
+	}
+}
+
+// Start registers informers for the given workload kinds, launches all informers,
+// and blocks until their caches are synced. Only the requested kinds are watched,
+// so RBAC permissions are needed only for resources actually monitored.
+// Calling Start again with additional kinds starts only the new informers.
+// Transient list/watch failures are retried by the informers for up to syncRetryTimeout.
+// Returns an error if access is denied (RBAC, credentials), the API server stays
+// unreachable, or ctx is cancelled.
+func (r *DeploymentRepository) Start(ctx context.Context, kinds ...types.WorkloadKind) error {
+	apps := r.factory.Apps().V1()
+
+	// A failure reported after an earlier sync has been retried by the informers since
+	select {
+	case <-r.watchErrors:
+	default:
+	}
+
+	for _, kind := range kinds {
+		switch kind {
+		case types.KindDeployment:
+			r.deployments = apps.Deployments().Lister()
+			r.replicaSets = apps.ReplicaSets().Lister()
+			r.register(apps.Deployments().Informer(), apps.ReplicaSets().Informer())
+		case types.KindStatefulSet:
+			r.statefulSets = apps.StatefulSets().Lister()
+			r.controllerRevisions = apps.ControllerRevisions().Lister()
+			r.register(apps.StatefulSets().Informer(), apps.ControllerRevisions().Informer())
+		case types.KindDaemonSet:
+			r.daemonSets = apps.DaemonSets().Lister()
+			r.controllerRevisions = apps.ControllerRevisions().Lister()
+			r.register(apps.DaemonSets().Informer(), apps.ControllerRevisions().Informer())
+		}
+	}
+
+	syncCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r.factory.Start(ctx.Done())
+
+	synced := make(chan bool, 1)
+
+	go func() {
+		allSynced := true
+		for _, ok := range r.factory.WaitForCacheSync(syncCtx.Done()) {
+			allSynced = allSynced && ok
+		}
+
+		synced <- allSynced
+	}()
+
+	return r.waitForSync(ctx, synced)
+}
+
+// waitForSync waits for the initial cache sync, retrying failed lists and watches.
+// Informers may retry some failures without reporting them, so the API server is also
+// probed while waiting. Failures are given up on after syncRetryTimeout without a success.
+func (r *DeploymentRepository) waitForSync(ctx context.Context, synced <-chan bool) error {
+	var (
+		lastErr  error
+		deadline <-chan time.Time // Armed by the first failure, disarmed by a successful probe
+	)
+
+	retryTimer := time.NewTimer(syncRetryTimeout)
+	retryTimer.Stop()
+
+	defer retryTimer.Stop()
+
+	probe := time.NewTicker(syncProbeInterval)
+	defer probe.Stop()
+
+	for {
+		var err error
+
+		select {
+		case err = <-r.watchErrors:
+		case <-probe.C:
+			err = r.probeAPIServer(ctx)
+			if err == nil {
+				retryTimer.Stop()
+				lastErr, deadline = nil, nil
+
+				continue
+			}
+		case <-deadline:
+			return Classify(ErrClusterUnavailable, fmt.Errorf("failed to watch resources in namespace '%s' within %s: %w",
+				r.namespace, types.FormatDuration(syncRetryTimeout), lastErr))
+		case ok := <-synced:
+			if ok {
+				return nil
+			}
+
+			if ctx.Err() != nil && lastErr != nil {
+				return fmt.Errorf("failed to watch resources in namespace '%s': %w", r.namespace, lastErr)
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			return Classify(ErrClusterUnavailable, errors.New("failed to sync resource caches"))
+		}
+
+		if isPersistentAPIError(err) {
+			return fmt.Errorf("failed to watch resources in namespace '%s': %w", r.namespace, classifyAPIError(err))
+		}
+
+		if deadline == nil {
+			retryTimer.Reset(syncRetryTimeout)
+			deadline = retryTimer.C
+		}
+
+		lastErr = err
+
+		if r.syncRetry != nil {
+			r.syncRetry(err)
+		}
+	}
+}
+
+// probeAPIServer checks that the API server answers requests, within syncProbeInterval.
+// Clients without a REST client (fake clientsets) are assumed reachable.
+func (r *DeploymentRepository) probeAPIServer(ctx context.Context) error {
+	client := r.clientset.Discovery().RESTClient()
+	if client == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, syncProbeInterval)
+	defer cancel()
+
+	err := client.Get().AbsPath("/version").Do(ctx).Error()
+	if err != nil && ctx.Err() != nil {
+		return nil // Cancelled, or too slow to tell: leave it to the next probe
+	}
+
+	return err
+}
+
+// Updates returns a channel that receives a signal whenever any watched object changes.
+// Bursts of changes are coalesced into a single pending signal.
+func (r *DeploymentRepository) Updates() <-chan struct{} {
+	return r.updates
 }
 
-// NewDeploymentRepository creates a new repository instance
-func NewDeploymentRepository(clientset *kubernetes.Clientset, namespace string) *DeploymentRepository {
-	return &DeploymentRepository{
-		clientset: clientset,
-		namespace: namespace,
+// notify signals a cache change without blocking informer handlers.
+func (r *DeploymentRepository) notify() {
+	select {
+	case r.updates <- struct{}{}:
+	default:
 	}
 }
 
-// GetDeployment retrieves a deployment by name from the Kubernetes API
-func (r *DeploymentRepository) GetDeployment(ctx context.Context, name string) (*appsv1.Deployment, error) {
-	deployment, err := r.clientset.AppsV1().Deployments(r.namespace).Get(ctx, name, metav1.GetOptions{})
+// SetAPIErrorHandler registers a hook called on every failed API request: watch/list failures
+// (even those the reflectors retry), log fetches and rollbacks. Must be called before Start.
+func (r *DeploymentRepository) SetAPIErrorHandler(handler func(operation string)) {
+	r.apiErrors = handler
+}
+
+// SetSyncRetryHandler registers a hook called whenever a failed list or watch is retried
+// while Start waits for the initial sync. Must be called before Start.
+func (r *DeploymentRepository) SetSyncRetryHandler(handler func(err error)) {
+	r.syncRetry = handler
+}
+
+// reportAPIError calls the API error hook, if any.
+func (r *DeploymentRepository) reportAPIError(operation string) {
+	if r.apiErrors != nil {
+		r.apiErrors(operation)
+	}
+}
+
+// reportWatchError records a watch failure so Start can surface it.
+// Failures while one is already pending are retried by the reflectors and intentionally dropped.
+func (r *DeploymentRepository) reportWatchError(err error) {
+	r.reportAPIError("watch")
+
+	select {
+	case r.watchErrors <- err:
+	default:
+	}
+}
+
+// GetDeployment retrieves a deployment by name from the informer cache
+func (r *DeploymentRepository) GetDeployment(_ context.Context, name string) (*appsv1.Deployment, error) {
+	deployment, err := r.deployments.Deployments(r.namespace).Get(name)
 	if err != nil {
-		return nil, fmt.Errorf("deployment '%s' not found in namespace '%s': %w", name, r.namespace, err)
+		return nil, fmt.Errorf("deployment '%s' not found in namespace '%s': %w", name, r.namespace, classifyAPIError(err))
 	}
 
 	return deployment, nil
 }
 
-// FindActiveRollout finds the first deployment with an active rollout in the namespace.
-// Returns empty string if no active rollouts are found.
-func (r *DeploymentRepository) FindActiveRollout(ctx context.Context) (string, error) {
-	deployments, err := r.clientset.AppsV1().Deployments(r.namespace).List(ctx, metav1.ListOptions{})
+// ListDeployments returns names of deployments matching the label selector, sorted by name.
+func (r *DeploymentRepository) ListDeployments(_ context.Context, selector labels.Selector) ([]string, error) {
+	deployments, err := r.deployments.Deployments(r.namespace).List(selector)
 	if err != nil {
-		return "", fmt.Errorf("failed to list deployments in namespace '%s': %w", r.namespace, err)
+		return nil, fmt.Errorf("failed to list deployments in namespace '%s': %w", r.namespace, err)
+	}
+
+	names := make([]string, 0, len(deployments))
+	for _, deployment := range deployments {
+		names = append(names, deployment.Name)
 	}
 
-	for _, deployment := range deployments.Items {
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// FindActiveRollouts returns names of deployments with an active rollout in the namespace, sorted by name.
+// A rollout is active while the controller has not observed the latest spec,
+// or the deployment is neither complete nor failed.
+func (r *DeploymentRepository) FindActiveRollouts(_ context.Context) ([]string, error) {
+	deployments, err := r.deployments.Deployments(r.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments in namespace '%s': %w", r.namespace, err)
+	}
+
+	var names []string
+
+	for _, deployment := range deployments {
 		status := deployment.Status
-		// Active rollout = not complete and not failed
-		if !isDeploymentComplete(status) && !isDeploymentFailed(status) {
-			return deployment.Name, nil
+		if status.ObservedGeneration < deployment.Generation ||
+			(!isDeploymentComplete(status) && !isDeploymentFailed(status)) {
+			names = append(names, deployment.Name)
 		}
 	}
 
-	return "", nil
+	slices.Sort(names)
+
+	return names, nil
 }
 
 // GetReplicaSets returns old and new ReplicaSets for a deployment.
 // Returns the newest ReplicaSet and a list of older active ReplicaSets.
 func (r *DeploymentRepository) GetReplicaSets(
-	ctx context.Context,
+	_ context.Context,
 	deployment *appsv1.Deployment,
 ) ([]*appsv1.ReplicaSet, *appsv1.ReplicaSet, error) {
-	replicaSets, err := r.clientset.AppsV1().ReplicaSets(r.namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: metav1.FormatLabelSelector(deployment.Spec.Selector),
-	})
+	replicaSets, err := r.listReplicaSetsByRevision(deployment)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to fetch ReplicaSets for deployment '%s': %w", deployment.Name, err)
+		return nil, nil, err
 	}
 
-	var (
-		oldRS []*appsv1.ReplicaSet
-		newRS *appsv1.ReplicaSet
-	)
+	if len(replicaSets) == 0 {
+		return nil, nil, nil
+	}
+
+	newest := len(replicaSets) - 1
+
+	return filterActiveReplicaSets(replicaSets[:newest]), replicaSets[newest], nil
+}
+
+// listReplicaSetsByRevision returns ReplicaSets controlled by the deployment, sorted by ascending revision.
+func (r *DeploymentRepository) listReplicaSetsByRevision(deployment *appsv1.Deployment) ([]*appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for deployment '%s': %w", deployment.Name, err)
+	}
 
-	maxRevision := int64(0)
+	replicaSets, err := r.replicaSets.ReplicaSets(r.namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ReplicaSets for deployment '%s': %w", deployment.Name, err)
+	}
 
-	for i := range replicaSets.Items {
-		rs := &replicaSets.Items[i]
+	var (
+		owned     []*appsv1.ReplicaSet
+		revisions = make(map[*appsv1.ReplicaSet]int64)
+	)
 
+	for _, rs := range replicaSets {
 		// Check if owned by deployment
 		if !metav1.IsControlledBy(rs, deployment) {
 			continue
 		}
 
-		// Get revision
-		revision := int64(0)
+		revision, err := replicaSetRevision(rs)
+		if err != nil {
+			return nil, fmt.Errorf("%w in deployment '%s'", err, deployment.Name)
+		}
 
-		if revisionStr, ok := rs.Annotations[RevisionAnnotation]; ok {
-			parsedRev, err := strconv.ParseInt(revisionStr, parseIntBase10, parseIntBits64)
-			if err != nil {
-				return nil, nil, fmt.Errorf("failed to parse revision %q for ReplicaSet %s in deployment '%s': %w",
-					revisionStr, rs.Name, deployment.Name, err)
-			}
+		owned = append(owned, rs)
+		revisions[rs] = revision
+	}
+
+	sort.SliceStable(owned, func(i, j int) bool {
+		return revisions[owned[i]] < revisions[owned[j]]
+	})
+
+	return owned, nil
+}
+
+// replicaSetRevision parses the deployment revision annotation of a ReplicaSet (0 if absent).
+func replicaSetRevision(rs *appsv1.ReplicaSet) (int64, error) {
+	revisionStr, ok := rs.Annotations[RevisionAnnotation]
+	if !ok {
+		return 0, nil
+	}
+
+	revision, err := strconv.ParseInt(revisionStr, parseIntBase10, parseIntBits64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse revision %q for ReplicaSet %s: %w", revisionStr, rs.Name, err)
+	}
+
+	return revision, nil
+}
 
-			revision = parsedRev
+// RollbackDeployment reverts the deployment's pod template to the previous revision's,
+// equivalent to `kubectl rollout undo`. The deployment is re-read from the API server and
+// updated with conflict retries, as the cached copy may be stale.
+// Returns the revision rolled back to and the deployment generation carrying the rollback.
+func (r *DeploymentRepository) RollbackDeployment(ctx context.Context, name string) (int64, int64, error) {
+	cached, err := r.GetDeployment(ctx, name)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	replicaSets, err := r.listReplicaSetsByRevision(cached)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(replicaSets) < 2 { //nolint:mnd // need the current and a previous revision
+		return 0, 0, fmt.Errorf("deployment '%s' has no previous revision to roll back to", name)
+	}
+
+	previous := replicaSets[len(replicaSets)-2]
+
+	revision, err := replicaSetRevision(previous)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	template := previous.Spec.Template.DeepCopy()
+	delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	var generation int64
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		deployment, err := r.clientset.AppsV1().Deployments(r.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return err
 		}
 
-		if revision > maxRevision {
-			if newRS != nil {
-				oldRS = append(oldRS, newRS)
-			}
+		if deployment.Spec.Paused {
+			return fmt.Errorf("deployment '%s' is paused, resume it before rolling back", name)
+		}
+
+		deployment.Spec.Template = *template
 
-			maxRevision = revision
-			newRS = rs
-		} else {
-			oldRS = append(oldRS, rs)
+		updated, err := r.clientset.AppsV1().Deployments(r.namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+		if err != nil {
+			return err
 		}
+
+		generation = updated.Generation
+
+		return nil
+	})
+	if err != nil {
+		r.reportAPIError("rollback")
+
+		return 0, 0, fmt.Errorf("failed to roll back deployment '%s' to revision %d: %w",
+			name, revision, classifyAPIError(err))
 	}
 
-	return filterActiveReplicaSets(oldRS), newRS, nil
+	return revision, generation, nil
 }
 
 // filterActiveReplicaSets returns only ReplicaSets with desired replicas > 0.
@@ -127,44 +487,181 @@ func filterActiveReplicaSets(rss []*appsv1.ReplicaSet) []*appsv1.ReplicaSet {
 	return active
 }
 
-// GetPodEvents fetches events for pods belonging to a specific ReplicaSet.
-// Returns raw K8s events without any processing - ProcessEvents handles everything else.
-func (r *DeploymentRepository) GetPodEvents(ctx context.Context, rs *appsv1.ReplicaSet) ([]corev1.Event, error) {
-	// Use label selector from ReplicaSet to filter pods
-	labelSelector := metav1.FormatLabelSelector(rs.Spec.Selector)
+// GetPods returns pods matching the selector that are controlled by owner.
+func (r *DeploymentRepository) GetPods(
+	_ context.Context,
+	labelSelector *metav1.LabelSelector,
+	owner metav1.Object,
+) ([]*corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for '%s': %w", owner.GetName(), err)
+	}
 
-	pods, err := r.clientset.CoreV1().Pods(r.namespace).List(ctx, metav1.ListOptions{
-		LabelSelector: labelSelector,
-	})
+	pods, err := r.pods.Pods(r.namespace).List(selector)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pods for ReplicaSet '%s': %w", rs.Name, err)
+		return nil, fmt.Errorf("failed to list pods: %w", err)
 	}
 
-	// Build set of pod names for this ReplicaSet
-	podNames := make(map[string]struct{}, len(pods.Items))
-	for _, pod := range pods.Items {
+	var result []*corev1.Pod
+
+	for _, pod := range pods {
 		// Double-check ownership to be safe
-		if metav1.IsControlledBy(&pod, rs) {
-			podNames[pod.Name] = struct{}{}
+		if metav1.IsControlledBy(pod, owner) {
+			result = append(result, pod)
 		}
 	}
 
-	// Fetch all events for Pods
-	eventList, err := r.clientset.CoreV1().Events(r.namespace).List(ctx, metav1.ListOptions{
-		FieldSelector: "involvedObject.kind=Pod",
-	})
+	return result, nil
+}
+
+// GetEventsForPods returns cached events whose involved object is one of the given pods.
+func (r *DeploymentRepository) GetEventsForPods(ctx context.Context, pods []*corev1.Pod) ([]corev1.Event, error) {
+	var result []corev1.Event
+
+	for _, pod := range pods {
+		events, err := r.GetEventsForObject(ctx, "Pod", pod)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, events...)
+	}
+
+	return result, nil
+}
+
+// GetEventsForObject returns cached events whose involved object is obj of the given kind.
+// Events of an earlier object with the same name (deleted and recreated) are skipped.
+func (r *DeploymentRepository) GetEventsForObject(
+	_ context.Context,
+	kind string,
+	obj metav1.Object,
+) ([]corev1.Event, error) {
+	events, err := r.events.ByIndex(eventsByInvolvedObjectIndex, kind+"/"+obj.GetName())
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pod events: %w", err)
+		return nil, fmt.Errorf("failed to fetch %s events: %w", strings.ToLower(kind), err)
 	}
 
-	// Filter to events for this ReplicaSet's pods
 	var result []corev1.Event
 
-	for _, event := range eventList.Items {
-		if _, ok := podNames[event.InvolvedObject.Name]; ok {
-			result = append(result, event)
+	for _, item := range events {
+		event, ok := item.(*corev1.Event)
+		if !ok || (event.InvolvedObject.UID != "" && event.InvolvedObject.UID != obj.GetUID()) {
+			continue
 		}
+
+		result = append(result, *event)
 	}
 
 	return result, nil
 }
+
+// GetContainerLogs fetches the last tailLines log lines of a pod container from the API server.
+// With previous set, returns logs of the container's previous, terminated instance.
+// Logs are not cached by informers, so every call is an API request.
+func (r *DeploymentRepository) GetContainerLogs(
+	ctx context.Context,
+	podName, container string,
+	previous bool,
+	tailLines int64,
+) ([]string, error) {
+	raw, err := r.clientset.CoreV1().Pods(r.namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+		Previous:  previous,
+		TailLines: &tailLines,
+	}).DoRaw(ctx)
+	if err != nil {
+		r.reportAPIError("logs")
+
+		return nil, fmt.Errorf("failed to fetch logs of container '%s' in pod '%s': %w", container, podName, err)
+	}
+
+	return strings.Split(strings.TrimRight(string(raw), "\n"), "\n"), nil
+}
+
+// GetStatefulSet retrieves a StatefulSet by name from the informer cache
+func (r *DeploymentRepository) GetStatefulSet(_ context.Context, name string) (*appsv1.StatefulSet, error) {
+	sts, err := r.statefulSets.StatefulSets(r.namespace).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("statefulset '%s' not found in namespace '%s': %w", name, r.namespace, classifyAPIError(err))
+	}
+
+	return sts, nil
+}
+
+// GetDaemonSet retrieves a DaemonSet by name from the informer cache
+func (r *DeploymentRepository) GetDaemonSet(_ context.Context, name string) (*appsv1.DaemonSet, error) {
+	ds, err := r.daemonSets.DaemonSets(r.namespace).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("daemonset '%s' not found in namespace '%s': %w", name, r.namespace, classifyAPIError(err))
+	}
+
+	return ds, nil
+}
+
+// GetNewestControllerRevision returns the highest-numbered ControllerRevision controlled by owner.
+// DaemonSets do not report their update revision in status, so it is derived from history.
+// Returns nil if the owner has no revisions yet.
+func (r *DeploymentRepository) GetNewestControllerRevision(
+	_ context.Context,
+	labelSelector *metav1.LabelSelector,
+	owner metav1.Object,
+) (*appsv1.ControllerRevision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector for '%s': %w", owner.GetName(), err)
+	}
+
+	revisions, err := r.controllerRevisions.ControllerRevisions(r.namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list controller revisions for '%s': %w", owner.GetName(), err)
+	}
+
+	var newest *appsv1.ControllerRevision
+
+	for _, revision := range revisions {
+		if !metav1.IsControlledBy(revision, owner) {
+			continue
+		}
+
+		if newest == nil || revision.Revision > newest.Revision {
+			newest = revision
+		}
+	}
+
+	return newest, nil
+}
+
+// GetControllerRevision retrieves a ControllerRevision by name from the informer cache
+func (r *DeploymentRepository) GetControllerRevision(
+	_ context.Context,
+	name string,
+) (*appsv1.ControllerRevision, error) {
+	revision, err := r.controllerRevisions.ControllerRevisions(r.namespace).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("controller revision '%s' not found in namespace '%s': %w",
+			name, r.namespace, classifyAPIError(err))
+	}
+
+	return revision, nil
+}
+
+// indexEventByInvolvedObject is the indexer function for eventsByInvolvedObjectIndex.
+func indexEventByInvolvedObject(obj any) ([]string, error) {
+	event, ok := obj.(*corev1.Event)
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name}, nil
+}
+
+// stripManagedFields drops managedFields before objects enter the cache to reduce memory usage.
+func stripManagedFields(obj any) (any, error) {
+	if accessor, ok := obj.(metav1.Object); ok {
+		accessor.SetManagedFields(nil)
+	}
+
+	return obj, nil
+}
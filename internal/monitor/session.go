@@ -0,0 +1,229 @@
+package monitor
+
+// This file contains recording of rollout sessions and their replay through a view.
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// sessionVersion identifies the session recording layout (see sessionSnapshot).
+// Adding fields keeps the version, replay ignores fields it does not know; renaming, removing
+// or changing the meaning of a field bumps it. Recordings with a newer version are rejected.
+const sessionVersion = 1
+
+// sessionHeader is the first line of a session recording.
+type sessionHeader struct {
+	Version     int       `json:"version"`
+	Started     time.Time `json:"started"`
+	MultiTarget bool      `json:"multiTarget"` // Replay through the multi-workload dashboard
+}
+
+// sessionRecord is one rendered snapshot with the raw objects it was built from
+// that were added or changed since the previous record.
+type sessionRecord struct {
+	Snapshot *sessionSnapshot `json:"snapshot"`
+	Objects  []sessionObject  `json:"objects,omitempty"`
+}
+
+// sessionObject is a raw Kubernetes object, tagged with its kind as informer objects carry no TypeMeta.
+type sessionObject struct {
+	Kind   string        `json:"kind"`
+	Object metav1.Object `json:"object"`
+}
+
+// sessionObjects tags objects of one kind for recording.
+func sessionObjects[T metav1.Object](kind string, objects ...T) []sessionObject {
+	result := make([]sessionObject, 0, len(objects))
+	for _, obj := range objects {
+		result = append(result, sessionObject{Kind: kind, Object: obj})
+	}
+
+	return result
+}
+
+// sessionEvents tags events for recording.
+func sessionEvents(events []corev1.Event) []sessionObject {
+	result := make([]sessionObject, 0, len(events))
+	for i := range events {
+		result = append(result, sessionObject{Kind: "Event", Object: &events[i]})
+	}
+
+	return result
+}
+
+// sessionRecorder writes rendered snapshots as JSON Lines, preceded by a session header.
+type sessionRecorder struct {
+	encoder     *json.Encoder
+	multiTarget bool
+	started     bool              // Header written
+	versions    map[string]string // Resource version of every recorded object by "Kind/name"
+}
+
+// newSessionRecorder creates a recorder writing to w.
+func newSessionRecorder(w io.Writer, multiTarget bool) *sessionRecorder {
+	return &sessionRecorder{encoder: json.NewEncoder(w), multiTarget: multiTarget, versions: make(map[string]string)}
+}
+
+// record appends a snapshot to the recording, with those of its raw objects
+// that were not recorded yet in their current version.
+func (r *sessionRecorder) record(snapshot *types.RolloutSnapshot, objects []sessionObject) error {
+	if !r.started {
+		header := sessionHeader{Version: sessionVersion, Started: snapshot.SnapshotTime, MultiTarget: r.multiTarget}
+
+		err := r.encoder.Encode(header)
+		if err != nil {
+			return err
+		}
+
+		r.started = true
+	}
+
+	var changed []sessionObject
+
+	for _, obj := range objects {
+		key := obj.Kind + "/" + obj.Object.GetName()
+		if r.versions[key] != obj.Object.GetResourceVersion() {
+			r.versions[key] = obj.Object.GetResourceVersion()
+			changed = append(changed, obj)
+		}
+	}
+
+	recorded := newSessionSnapshot(snapshot)
+
+	return r.encoder.Encode(sessionRecord{Snapshot: &recorded, Objects: changed})
+}
+
+// capture remembers the raw objects the tracker's next snapshot is built from, when recording.
+func (c *Controller) capture(t *rolloutTracker, objects ...[]sessionObject) {
+	if c.recorder == nil {
+		return
+	}
+
+	t.sources = nil
+	for _, group := range objects {
+		t.sources = append(t.sources, group...)
+	}
+}
+
+// Replay renders a recorded session through the view selected by config.Output.
+// Snapshots are paced by their recorded timestamps divided by speed; speed 0 renders them without delay.
+// Each snapshot is shifted to the current time, so ages and ETAs read as they did when recorded.
+// The TUI keeps showing the last snapshot until the user quits.
+func Replay(ctx context.Context, r io.Reader, config Config, speed float64) error {
+	decoder := json.NewDecoder(r)
+
+	var header sessionHeader
+
+	err := decoder.Decode(&header)
+	if err != nil {
+		return fmt.Errorf("failed to read session header: %w", err)
+	}
+
+	if header.Version < 1 || header.Version > sessionVersion {
+		return fmt.Errorf("unsupported session recording version %d (supported up to %d)", header.Version, sessionVersion)
+	}
+
+	config.MultiTarget = header.MultiTarget
+
+	view, err := newView(config)
+	if err != nil {
+		return err
+	}
+	defer view.Shutdown()
+
+	var last time.Time
+
+	for {
+		// Raw objects are kept for postmortems, replay only needs the snapshot
+		var record struct {
+			Snapshot *sessionSnapshot `json:"snapshot"`
+		}
+
+		err := decoder.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read session record: %w", err)
+		}
+
+		if record.Snapshot == nil {
+			continue
+		}
+
+		snapshot, err := record.Snapshot.rolloutSnapshot()
+		if err != nil {
+			return fmt.Errorf("failed to read session record: %w", err)
+		}
+
+		recorded := snapshot.SnapshotTime
+		if !last.IsZero() && speed > 0 {
+			select {
+			case <-ctx.Done():
+				return ErrCancelled
+			case <-view.Done():
+				return nil
+			case <-time.After(time.Duration(float64(recorded.Sub(last)) / speed)):
+			}
+		}
+
+		last = recorded
+
+		shiftSnapshot(snapshot, time.Since(recorded))
+		view.RenderSnapshot(snapshot)
+	}
+
+	if !config.Output.IsInteractive() {
+		return nil
+	}
+
+	select {
+	case <-ctx.Done():
+		return ErrCancelled
+	case <-view.Done():
+		return nil
+	}
+}
+
+// shiftSnapshot moves every timestamp of a snapshot by d.
+func shiftSnapshot(s *types.RolloutSnapshot, d time.Duration) {
+	s.StartTime = s.StartTime.Add(d)
+	s.SnapshotTime = s.SnapshotTime.Add(d)
+
+	for _, t := range []*time.Time{s.ProgressUpdateTime, s.EstimatedCompletion} {
+		if t != nil {
+			*t = t.Add(d)
+		}
+	}
+
+	for i := range s.Events.Clusters {
+		c := &s.Events.Clusters[i]
+		c.LastSeen = c.LastSeen.Add(d)
+		c.FirstSeen = c.FirstSeen.Add(d)
+
+		for j := range c.Exemplars {
+			c.Exemplars[j].LastSeen = c.Exemplars[j].LastSeen.Add(d)
+		}
+	}
+
+	for i := range s.Pods {
+		pod := &s.Pods[i]
+		if !pod.Created.IsZero() {
+			pod.Created = pod.Created.Add(d)
+		}
+
+		for j := range pod.Events {
+			pod.Events[j].LastSeen = pod.Events[j].LastSeen.Add(d)
+		}
+	}
+}
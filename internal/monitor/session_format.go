@@ -0,0 +1,301 @@
+package monitor
+
+// This file contains the stable layout of snapshots in session recordings.
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+// sessionSnapshot is a rollout snapshot as stored in a session recording.
+// Field names are part of the recording format - do not rename (see sessionVersion).
+type sessionSnapshot struct {
+	Time                time.Time           `json:"time"`
+	Kind                string              `json:"kind"`
+	Name                string              `json:"name"`
+	RevisionName        string              `json:"revisionName"` // New ReplicaSet or update ControllerRevision
+	Revision            int64               `json:"revision,omitempty"`
+	Strategy            jsonStrategy        `json:"strategy"`
+	Desired             int32               `json:"desired"`
+	New                 jsonReplicaState    `json:"new"`
+	Old                 jsonReplicaState    `json:"old"`
+	Pods                []sessionPod        `json:"pods,omitempty"`
+	Progress            jsonProgress        `json:"progress"`
+	StartTime           time.Time           `json:"startTime"`
+	ProgressUpdateTime  *time.Time          `json:"progressUpdateTime,omitempty"`
+	EstimatedCompletion *time.Time          `json:"eta,omitempty"`
+	Status              string              `json:"status"`
+	StatusMessage       string              `json:"statusMessage,omitempty"`
+	Waiting             bool                `json:"waiting,omitempty"`
+	RollbackRevision    int64               `json:"rollbackRevision,omitempty"`
+	Events              sessionEventSummary `json:"events"`
+	Problems            []jsonProblem       `json:"problems,omitempty"`
+	Logs                jsonLogs            `json:"logs"`
+	History             sessionHistory      `json:"history"`
+}
+
+type sessionPod struct {
+	Name           string                 `json:"name"`
+	Node           string                 `json:"node,omitempty"`
+	New            bool                   `json:"new"`
+	State          string                 `json:"state"`
+	IP             string                 `json:"ip,omitempty"`
+	Phase          string                 `json:"phase,omitempty"`
+	Created        time.Time              `json:"created"`
+	Containers     []sessionContainer     `json:"containers,omitempty"`
+	ReadinessGates []sessionReadinessGate `json:"readinessGates,omitempty"`
+	Events         []sessionPodEvent      `json:"events,omitempty"`
+}
+
+type sessionContainer struct {
+	Name     string `json:"name"`
+	Init     bool   `json:"init,omitempty"`
+	Ready    bool   `json:"ready"`
+	State    string `json:"state,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Message  string `json:"message,omitempty"`
+	ExitCode int32  `json:"exitCode,omitempty"`
+	Restarts int32  `json:"restarts"`
+}
+
+type sessionReadinessGate struct {
+	Condition string `json:"condition"`
+	Ready     bool   `json:"ready"`
+}
+
+type sessionPodEvent struct {
+	Type     string    `json:"type"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Count    int32     `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type sessionEventSummary struct {
+	Clusters []sessionEventCluster `json:"clusters"`
+	Ignored  int                   `json:"ignored"`
+}
+
+type sessionEventCluster struct {
+	Source    string            `json:"source"`
+	Type      string            `json:"type"`
+	Reason    string            `json:"reason"`
+	Message   string            `json:"message"`
+	Count     int               `json:"count"`
+	FirstSeen time.Time         `json:"firstSeen"`
+	LastSeen  time.Time         `json:"lastSeen"`
+	Exemplars []sessionExemplar `json:"exemplars,omitempty"`
+	Pods      []string          `json:"pods,omitempty"`
+}
+
+type sessionExemplar struct {
+	Message  string    `json:"message"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+type sessionHistory struct {
+	Samples    int     `json:"samples"`
+	P50Seconds float64 `json:"p50Seconds,omitempty"`
+	P90Seconds float64 `json:"p90Seconds,omitempty"`
+}
+
+// sessionPodStates names pod states in recordings, indexed by types.PodState.
+var sessionPodStates = []string{"absent", "current", "ready", "available"}
+
+// sessionStatuses lists the rollout statuses a recording can name (see jsonStatus).
+var sessionStatuses = []types.RolloutStatus{
+	types.StatusProgressing, types.StatusDeadlineExceeded, types.StatusComplete,
+	types.StatusPaused, types.StatusReplicaFailure, types.StatusSuperseded,
+}
+
+// newSessionSnapshot converts a snapshot into its recorded representation.
+func newSessionSnapshot(s *types.RolloutSnapshot) sessionSnapshot {
+	record := newJSONRecord(s)
+
+	snapshot := sessionSnapshot{
+		Time:                s.SnapshotTime,
+		Kind:                string(s.Kind),
+		Name:                s.WorkloadName,
+		RevisionName:        s.NewRSName,
+		Revision:            s.Revision,
+		Strategy:            record.Strategy,
+		Desired:             s.Desired,
+		New:                 jsonReplicaState(s.NewRS),
+		Old:                 jsonReplicaState(s.OldRS),
+		Progress:            record.Progress,
+		StartTime:           s.StartTime,
+		ProgressUpdateTime:  s.ProgressUpdateTime,
+		EstimatedCompletion: s.EstimatedCompletion,
+		Status:              record.Status,
+		StatusMessage:       s.StatusMessage,
+		Waiting:             s.Waiting,
+		RollbackRevision:    s.RollbackRevision,
+		Events:              sessionEventSummary{Ignored: s.Events.IgnoredCount},
+		Problems:            record.Problems,
+		Logs:                record.Logs,
+		History: sessionHistory{
+			Samples:    s.History.Samples,
+			P50Seconds: s.History.P50.Seconds(),
+			P90Seconds: s.History.P90.Seconds(),
+		},
+	}
+
+	for _, p := range s.Pods {
+		pod := sessionPod{
+			Name:    p.Name,
+			Node:    p.Node,
+			New:     p.New,
+			State:   sessionPodStates[p.State],
+			IP:      p.IP,
+			Phase:   p.Phase,
+			Created: p.Created,
+		}
+
+		for _, c := range p.Containers {
+			pod.Containers = append(pod.Containers, sessionContainer(c))
+		}
+
+		for _, g := range p.ReadinessGates {
+			pod.ReadinessGates = append(pod.ReadinessGates, sessionReadinessGate(g))
+		}
+
+		for _, e := range p.Events {
+			pod.Events = append(pod.Events, sessionPodEvent(e))
+		}
+
+		snapshot.Pods = append(snapshot.Pods, pod)
+	}
+
+	for _, c := range s.Events.Clusters {
+		cluster := sessionEventCluster{
+			Source:    c.Source,
+			Type:      c.Type,
+			Reason:    c.Reason,
+			Message:   c.Message,
+			Count:     c.ExemplarCount,
+			FirstSeen: c.FirstSeen,
+			LastSeen:  c.LastSeen,
+			Pods:      c.Pods,
+		}
+
+		for _, e := range c.Exemplars {
+			cluster.Exemplars = append(cluster.Exemplars, sessionExemplar(e))
+		}
+
+		snapshot.Events.Clusters = append(snapshot.Events.Clusters, cluster)
+	}
+
+	return snapshot
+}
+
+// rolloutSnapshot converts a recorded snapshot back.
+// Returns an error for statuses and pod states this version does not know.
+func (r *sessionSnapshot) rolloutSnapshot() (*types.RolloutSnapshot, error) {
+	s := &types.RolloutSnapshot{
+		Kind:                types.WorkloadKind(r.Kind),
+		WorkloadName:        r.Name,
+		NewRSName:           r.RevisionName,
+		Revision:            r.Revision,
+		StrategyType:        r.Strategy.Type,
+		MaxSurge:            r.Strategy.MaxSurge,
+		MaxUnavailable:      r.Strategy.MaxUnavailable,
+		Partition:           r.Strategy.Partition,
+		Desired:             r.Desired,
+		NewRS:               types.ReplicaSetState(r.New),
+		OldRS:               types.ReplicaSetState(r.Old),
+		NewProgress:         r.Progress.New,
+		OldProgress:         r.Progress.Old,
+		StartTime:           r.StartTime,
+		SnapshotTime:        r.Time,
+		ProgressUpdateTime:  r.ProgressUpdateTime,
+		EstimatedCompletion: r.EstimatedCompletion,
+		StatusMessage:       r.StatusMessage,
+		Waiting:             r.Waiting,
+		RollbackRevision:    r.RollbackRevision,
+		Events:              types.EventSummary{IgnoredCount: r.Events.Ignored},
+		Logs:                types.LogSummary{Pods: r.Logs.Pods, Lines: r.Logs.Lines},
+		History: types.RolloutHistory{
+			Samples: r.History.Samples,
+			P50:     time.Duration(r.History.P50Seconds * float64(time.Second)),
+			P90:     time.Duration(r.History.P90Seconds * float64(time.Second)),
+		},
+	}
+
+	i := slices.IndexFunc(sessionStatuses, func(status types.RolloutStatus) bool { return jsonStatus(status) == r.Status })
+	if i < 0 {
+		return nil, fmt.Errorf("unknown rollout status '%s'", r.Status)
+	}
+
+	s.Status = sessionStatuses[i]
+
+	for _, p := range r.Pods {
+		state := slices.Index(sessionPodStates, p.State)
+		if state < 0 {
+			return nil, fmt.Errorf("unknown state '%s' of pod '%s'", p.State, p.Name)
+		}
+
+		pod := types.PodInfo{
+			Name:    p.Name,
+			Node:    p.Node,
+			New:     p.New,
+			State:   types.PodState(state),
+			IP:      p.IP,
+			Phase:   p.Phase,
+			Created: p.Created,
+		}
+
+		for _, c := range p.Containers {
+			pod.Containers = append(pod.Containers, types.ContainerInfo(c))
+		}
+
+		for _, g := range p.ReadinessGates {
+			pod.ReadinessGates = append(pod.ReadinessGates, types.ReadinessGate(g))
+		}
+
+		for _, e := range p.Events {
+			pod.Events = append(pod.Events, types.PodEvent(e))
+		}
+
+		s.Pods = append(s.Pods, pod)
+	}
+
+	for _, c := range r.Events.Clusters {
+		cluster := types.EventCluster{
+			Source:        c.Source,
+			Type:          c.Type,
+			Reason:        c.Reason,
+			Message:       c.Message,
+			ExemplarCount: c.Count,
+			FirstSeen:     c.FirstSeen,
+			LastSeen:      c.LastSeen,
+			Pods:          c.Pods,
+		}
+
+		for _, e := range c.Exemplars {
+			cluster.Exemplars = append(cluster.Exemplars, types.EventExemplar(e))
+		}
+
+		s.Events.Clusters = append(s.Events.Clusters, cluster)
+	}
+
+	for _, p := range r.Problems {
+		s.Problems = append(s.Problems, types.ContainerProblem{
+			Container:   p.Container,
+			Reason:      p.Reason,
+			Message:     p.Message,
+			Pods:        p.Pods,
+			Restarts:    p.Restarts,
+			Termination: (*types.ContainerTermination)(p.Termination),
+		})
+	}
+
+	for _, c := range r.Logs.Clusters {
+		s.Logs.Clusters = append(s.Logs.Clusters, types.LogCluster(c))
+	}
+
+	return s, nil
+}
@@ -0,0 +1,146 @@
+package monitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestSessionSnapshotRoundTrip checks that every snapshot field survives recording and replay.
+func TestSessionSnapshotRoundTrip(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		when := start.Add(d)
+
+		return &when
+	}
+	partition := int32(1)
+
+	want := &types.RolloutSnapshot{
+		Kind:           types.KindStatefulSet,
+		WorkloadName:   "db",
+		NewRSName:      "db-7c9f",
+		Revision:       3,
+		StrategyType:   "RollingUpdate",
+		MaxUnavailable: "1",
+		Partition:      &partition,
+		Desired:        3,
+		NewRS:          types.ReplicaSetState{Current: 2, Ready: 1, Available: 1},
+		OldRS:          types.ReplicaSetState{Current: 1, Ready: 1, Available: 1},
+		Pods: []types.PodInfo{{
+			Name: "db-2", Node: "node-1", New: true, State: types.PodReady,
+			IP: "10.0.0.2", Phase: "Running", Created: start,
+			Containers: []types.ContainerInfo{{
+				Name: "db", Ready: true, State: "Running", Reason: "Error", Message: "m", ExitCode: 1, Restarts: 2,
+			}},
+			ReadinessGates: []types.ReadinessGate{{Condition: "example.com/ready", Ready: true}},
+			Events:         []types.PodEvent{{Type: "Normal", Reason: "Pulled", Message: "pulled", Count: 1, LastSeen: start}},
+		}},
+		NewProgress:         0.5,
+		OldProgress:         0.5,
+		StartTime:           start,
+		SnapshotTime:        start.Add(time.Minute),
+		ProgressUpdateTime:  at(30 * time.Second),
+		EstimatedCompletion: at(2 * time.Minute),
+		Status:              types.StatusReplicaFailure,
+		StatusMessage:       "quota exceeded",
+		RollbackRevision:    2,
+		Events: types.EventSummary{
+			Clusters: []types.EventCluster{{
+				Source: types.PodEventSource, Type: "Warning", Reason: "BackOff", Message: "Back-off <*>",
+				ExemplarCount: 2, LastSeen: start.Add(time.Minute), FirstSeen: start,
+				Exemplars: []types.EventExemplar{{Message: "Back-off db", Count: 2, LastSeen: start}},
+				Pods:      []string{"db-2"},
+			}},
+			IgnoredCount: 1,
+		},
+		Problems: []types.ContainerProblem{{
+			Container: "db", Reason: "CrashLoopBackOff", Message: "back-off", Pods: []string{"db-2"}, Restarts: 2,
+			Termination: &types.ContainerTermination{Reason: "Error", ExitCode: 1, Message: "panic"},
+		}},
+		Logs: types.LogSummary{
+			Clusters: []types.LogCluster{{Container: "db", Previous: true, Template: "panic <*>", Count: 3}},
+			Pods:     []string{"db-2"},
+			Lines:    3,
+		},
+		History: types.RolloutHistory{Samples: 5, P50: 4 * time.Minute, P90: 6 * time.Minute},
+	}
+
+	data, err := json.Marshal(newSessionSnapshot(want))
+	if err != nil {
+		t.Fatalf("failed to encode snapshot: %v", err)
+	}
+
+	var recorded sessionSnapshot
+
+	err = json.Unmarshal(data, &recorded)
+	if err != nil {
+		t.Fatalf("failed to decode snapshot: %v", err)
+	}
+
+	got, err := recorded.rolloutSnapshot()
+	if err != nil {
+		t.Fatalf("rolloutSnapshot() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed snapshot = %+v\nwant %+v", got, want)
+	}
+}
+
+// TestSessionRecorderRecordsChangedObjects checks that each object version is recorded once.
+func TestSessionRecorderRecordsChangedObjects(t *testing.T) {
+	replicaSet := func(name, version string) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: name, ResourceVersion: version}}
+	}
+
+	var buf bytes.Buffer
+
+	recorder := newSessionRecorder(&buf, false)
+	snapshot := &types.RolloutSnapshot{Kind: types.KindDeployment, WorkloadName: "web"}
+
+	records := [][]sessionObject{
+		sessionObjects("ReplicaSet", replicaSet("web-1", "1"), replicaSet("web-2", "1")),
+		sessionObjects("ReplicaSet", replicaSet("web-1", "1"), replicaSet("web-2", "2")),
+		sessionObjects("ReplicaSet", replicaSet("web-1", "1"), replicaSet("web-2", "2")),
+	}
+
+	for _, objects := range records {
+		err := recorder.record(snapshot, objects)
+		if err != nil {
+			t.Fatalf("record() error = %v", err)
+		}
+	}
+
+	decoder := json.NewDecoder(&buf)
+
+	var header sessionHeader
+
+	err := decoder.Decode(&header)
+	if err != nil || header.Version != sessionVersion {
+		t.Fatalf("header = %+v (error %v), want version %d", header, err, sessionVersion)
+	}
+
+	want := []int{2, 1, 0}
+
+	for i, count := range want {
+		var record struct {
+			Objects []json.RawMessage `json:"objects"`
+		}
+
+		err := decoder.Decode(&record)
+		if err != nil {
+			t.Fatalf("failed to decode record %d: %v", i, err)
+		}
+
+		if len(record.Objects) != count {
+			t.Errorf("record %d has %d objects, want %d", i, len(record.Objects), count)
+		}
+	}
+}
@@ -6,11 +6,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
@@ -54,58 +57,6 @@ func parseStrategyParams(strategy appsv1.DeploymentStrategy) strategyParams {
 	return params
 }
 
-// updateETA calculates ETA with smooth countdown behavior.
-// Only recalculates when Available count changes; otherwise returns existing target.
-// This ensures ETA counts down smoothly between progress updates.
-func (c *Controller) updateETA(available, desired int32, startTime time.Time, rsName string) *time.Time {
-	// Reset state if ReplicaSet changed (new rollout started)
-	if rsName != c.etaLastRSName {
-		c.etaLastRSName = rsName
-		c.etaLastAvail = 0
-		c.etaTarget = nil
-	}
-
-	progress := calculateProgress(available, desired)
-
-	if progress < MinProgressForETA || progress >= 1.0 {
-		c.etaTarget = nil
-
-		return nil
-	}
-
-	// Only recalculate when available count actually changes
-	if available != c.etaLastAvail {
-		c.etaLastAvail = available
-
-		elapsed := time.Since(startTime)
-		if elapsed <= 0 {
-			return nil
-		}
-
-		rate := progress / elapsed.Seconds()
-		if rate <= 0 {
-			return nil
-		}
-
-		remainingSeconds := (1.0 - progress) / rate
-		if remainingSeconds >= float64(MaxRealisticETAHours*3600) {
-			c.etaTarget = nil
-
-			return nil
-		}
-
-		eta := time.Now().Add(time.Duration(remainingSeconds * float64(time.Second)))
-		c.etaTarget = &eta
-	}
-
-	// Return existing target - time.Until() will naturally count down
-	if c.etaTarget != nil && time.Until(*c.etaTarget) <= 0 {
-		return nil // Don't show negative/zero ETA
-	}
-
-	return c.etaTarget
-}
-
 // aggregateOldRSState sums replica counts across all old ReplicaSets.
 func aggregateOldRSState(oldRSs []*appsv1.ReplicaSet) types.ReplicaSetState {
 	var state types.ReplicaSetState
@@ -128,9 +79,27 @@ func calculateProgress(available, desired int32) float64 {
 	return float64(available) / float64(desired)
 }
 
-// buildSnapshot constructs a RolloutSnapshot with all calculated data.
-func (c *Controller) buildSnapshot(ctx context.Context) (*types.RolloutSnapshot, error) {
-	deployment, err := c.repo.GetDeployment(ctx, c.deploymentName)
+// errSnapshotPending reports that the caches do not hold everything a snapshot needs yet,
+// as when one informer lags behind another. The target is skipped until the next change.
+var errSnapshotPending = errors.New("rollout data not available yet")
+
+// buildSnapshot constructs a RolloutSnapshot for the monitored workload kind.
+func (c *Controller) buildSnapshot(ctx context.Context, t *rolloutTracker) (*types.RolloutSnapshot, error) {
+	switch t.target.Kind {
+	case types.KindDeployment:
+		return c.buildDeploymentSnapshot(ctx, t)
+	case types.KindStatefulSet:
+		return c.buildStatefulSetSnapshot(ctx, t)
+	case types.KindDaemonSet:
+		return c.buildDaemonSetSnapshot(ctx, t)
+	}
+
+	return nil, fmt.Errorf("unsupported workload kind '%s'", t.target.Kind)
+}
+
+// buildDeploymentSnapshot constructs a RolloutSnapshot with all calculated data.
+func (c *Controller) buildDeploymentSnapshot(ctx context.Context, t *rolloutTracker) (*types.RolloutSnapshot, error) {
+	deployment, err := c.repo.GetDeployment(ctx, t.target.Name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch deployment: %w", err)
 	}
@@ -141,12 +110,29 @@ func (c *Controller) buildSnapshot(ctx context.Context) (*types.RolloutSnapshot,
 	}
 
 	if newRS == nil {
-		return nil, errors.New("no new ReplicaSet found for deployment")
+		return nil, fmt.Errorf("%w: no new ReplicaSet found for deployment", errSnapshotPending)
 	}
 
-	rawEvents, err := c.repo.GetPodEvents(ctx, newRS)
+	newPods, err := c.repo.GetPods(ctx, newRS.Spec.Selector, newRS)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch pod events: %w", err)
+		return nil, fmt.Errorf("failed to fetch pods for ReplicaSet '%s': %w", newRS.Name, err)
+	}
+
+	var oldPods []*corev1.Pod
+
+	for _, rs := range oldRSs {
+		pods, err := c.repo.GetPods(ctx, rs.Spec.Selector, rs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pods for ReplicaSet '%s': %w", rs.Name, err)
+		}
+
+		oldPods = append(oldPods, pods...)
+	}
+
+	rawEvents, err := c.collectEvents(ctx, newPods, newRS.CreationTimestamp.Time,
+		eventObject{kind: "ReplicaSet", obj: newRS}, eventObject{kind: "Deployment", obj: deployment})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
 	}
 
 	strategyParams := parseStrategyParams(deployment.Spec.Strategy)
@@ -164,26 +150,112 @@ func (c *Controller) buildSnapshot(ctx context.Context) (*types.RolloutSnapshot,
 
 	progressUpdateTime := getProgressUpdateTime(deployment)
 
+	status := CalculateRolloutStatus(deployment)
+	statusMessage := rolloutStatusMessage(deployment, status)
+
+	// The cache may not have caught up with the rollback yet, conditions still describe the failed rollout
+	if msg := generationMessage(deployment.Status.ObservedGeneration, t.rollbackGeneration); msg != "" {
+		status, statusMessage = types.StatusProgressing, msg
+	}
+
+	if !c.config.Match.IsZero() && t.rollbackRevision == 0 {
+		state, err := t.matchRollout(c.config.Match, deployment, newRS)
+		if err != nil {
+			return nil, err
+		}
+
+		switch state {
+		case matchPending:
+			status, statusMessage = types.StatusProgressing, "waiting for rollout of "+c.config.Match.String()
+		case matchSuperseded:
+			status = types.StatusSuperseded
+			statusMessage = fmt.Sprintf("rollout of %s superseded by ReplicaSet %s", c.config.Match, newRS.Name)
+		case matchActive:
+		}
+	}
+
+	revision, err := replicaSetRevision(newRS)
+	if err != nil {
+		return nil, err
+	}
+
+	// Counts come from ReplicaSet status, pods only back the pods grid
+	now := time.Now()
+	allPods := slices.Concat(newPods, oldPods)
+	isNew := func(pod *corev1.Pod) bool { return metav1.IsControlledBy(pod, newRS) }
+	breakdown := breakdownPods(allPods, isNew, deployment.Spec.MinReadySeconds, now)
+
+	err = c.attachPodEvents(ctx, breakdown.infos, allPods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pod events: %w", err)
+	}
+
+	c.capture(t,
+		sessionObjects("Deployment", deployment),
+		sessionObjects("ReplicaSet", newRS),
+		sessionObjects("ReplicaSet", oldRSs...),
+		sessionObjects("Pod", allPods...),
+		sessionEvents(rawEvents))
+
 	return &types.RolloutSnapshot{
-		DeploymentName:      deployment.Name,
-		NewRSName:           newRS.Name,
-		StrategyType:        string(deployment.Spec.Strategy.Type),
-		MaxSurge:            strategyParams.maxSurge,
-		MaxUnavailable:      strategyParams.maxUnavailable,
-		Desired:             desired,
-		NewRS:               newRSState,
-		OldRS:               oldRSState,
-		NewProgress:         newProgress,
-		OldProgress:         oldProgress,
-		StartTime:           newRS.CreationTimestamp.Time,
-		SnapshotTime:        time.Now(),
-		ProgressUpdateTime:  progressUpdateTime,
-		EstimatedCompletion: c.updateETA(newRSState.Available, desired, newRS.CreationTimestamp.Time, newRS.Name),
-		Status:              CalculateRolloutStatus(deployment),
-		Events:              SummarizeEvents(rawEvents, c.config.IgnoreEvents, c.config.SimilarityThreshold),
+		Kind:               types.KindDeployment,
+		WorkloadName:       deployment.Name,
+		NewRSName:          newRS.Name,
+		Revision:           revision,
+		StrategyType:       string(deployment.Spec.Strategy.Type),
+		MaxSurge:           strategyParams.maxSurge,
+		MaxUnavailable:     strategyParams.maxUnavailable,
+		Desired:            desired,
+		NewRS:              newRSState,
+		OldRS:              oldRSState,
+		Pods:               orderByRevision(breakdown.infos),
+		NewProgress:        newProgress,
+		OldProgress:        oldProgress,
+		StartTime:          newRS.CreationTimestamp.Time,
+		SnapshotTime:       now,
+		ProgressUpdateTime: progressUpdateTime,
+		Status:             status,
+		StatusMessage:      statusMessage,
+		RollbackRevision:   t.rollbackRevision,
+		Events:             SummarizeEvents(rawEvents, c.config.IgnoreEvents, c.config.SimilarityThreshold),
+		Problems:           DiagnoseContainers(newPods),
+		Logs:               c.summarizeFailingLogs(ctx, t, newPods),
 	}, nil
 }
 
+// eventObject is a workload object whose own events are merged with pod events.
+type eventObject struct {
+	kind string
+	obj  metav1.Object
+}
+
+// collectEvents returns events of new pods merged with events of the objects driving the rollout.
+// Quota rejections, admission webhook denials and PodSecurity failures are only reported on these
+// objects (e.g., FailedCreate on the ReplicaSet), as the pods never get created.
+// Object events last seen before since belong to earlier rollouts and are dropped.
+func (c *Controller) collectEvents(
+	ctx context.Context,
+	pods []*corev1.Pod,
+	since time.Time,
+	objects ...eventObject,
+) ([]corev1.Event, error) {
+	events, err := c.repo.GetEventsForPods(ctx, pods)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range objects {
+		objectEvents, err := c.repo.GetEventsForObject(ctx, o.kind, o.obj)
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, eventsSince(objectEvents, since)...)
+	}
+
+	return events, nil
+}
+
 func formatIntOrPercent(val intstr.IntOrString) string {
 	if val.Type == intstr.String {
 		return val.StrVal
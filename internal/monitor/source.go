@@ -0,0 +1,49 @@
+package monitor
+
+// This file contains the data source interface the controller builds snapshots from.
+
+import (
+	"context"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// RolloutSource provides the workloads, ReplicaSets, pods and events rollout snapshots are built from.
+// DeploymentRepository implements it on top of any kubernetes.Interface, including the fake
+// clientset from k8s.io/client-go/kubernetes/fake; simulated rollouts can implement it directly.
+type RolloutSource interface {
+	// Start begins watching the given workload kinds and blocks until the data is available.
+	Start(ctx context.Context, kinds ...types.WorkloadKind) error
+	// Updates signals that watched objects changed.
+	Updates() <-chan struct{}
+
+	GetDeployment(ctx context.Context, name string) (*appsv1.Deployment, error)
+	ListDeployments(ctx context.Context, selector labels.Selector) ([]string, error)
+	FindActiveRollouts(ctx context.Context) ([]string, error)
+	// GetReplicaSets returns the older active ReplicaSets and the newest one of a deployment.
+	GetReplicaSets(ctx context.Context, deployment *appsv1.Deployment) ([]*appsv1.ReplicaSet, *appsv1.ReplicaSet, error)
+	// RollbackDeployment reverts a deployment to its previous revision, returning that revision
+	// and the deployment generation carrying the rollback.
+	RollbackDeployment(ctx context.Context, name string) (int64, int64, error)
+
+	GetStatefulSet(ctx context.Context, name string) (*appsv1.StatefulSet, error)
+	GetDaemonSet(ctx context.Context, name string) (*appsv1.DaemonSet, error)
+	GetNewestControllerRevision(
+		ctx context.Context,
+		labelSelector *metav1.LabelSelector,
+		owner metav1.Object,
+	) (*appsv1.ControllerRevision, error)
+	GetControllerRevision(ctx context.Context, name string) (*appsv1.ControllerRevision, error)
+
+	// GetPods returns pods matching the selector and controlled by owner.
+	GetPods(ctx context.Context, labelSelector *metav1.LabelSelector, owner metav1.Object) ([]*corev1.Pod, error)
+	GetEventsForPods(ctx context.Context, pods []*corev1.Pod) ([]corev1.Event, error)
+	GetEventsForObject(ctx context.Context, kind string, obj metav1.Object) ([]corev1.Event, error)
+	GetContainerLogs(ctx context.Context, podName, container string, previous bool, tailLines int64) ([]string, error)
+}
+
+var _ RolloutSource = (*DeploymentRepository)(nil)
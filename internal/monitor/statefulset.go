@@ -0,0 +1,210 @@
+package monitor
+
+// This file contains StatefulSet rollout status and snapshot construction.
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// defaultStatefulSetMaxUnavailable reflects that StatefulSets update one ordinal at a time by default.
+const defaultStatefulSetMaxUnavailable = "1"
+
+// CalculateStatefulSetStatus determines rollout status from StatefulSet status fields.
+// Mirrors kubectl rollout status: StatefulSets have no progress deadline, so a rollout
+// is Progressing until all replicas are ready and updated (up to the partition).
+func CalculateStatefulSetStatus(sts *appsv1.StatefulSet) types.RolloutStatus {
+	status := sts.Status
+	desired := getInt32OrDefault(sts.Spec.Replicas, defaultReplicaCount)
+
+	if status.ObservedGeneration < sts.Generation {
+		return types.StatusProgressing
+	}
+
+	if status.ReadyReplicas < desired || status.AvailableReplicas < desired {
+		return types.StatusProgressing
+	}
+
+	if rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate != nil && rollingUpdate.Partition != nil {
+		if status.UpdatedReplicas < desired-*rollingUpdate.Partition {
+			return types.StatusProgressing
+		}
+
+		return types.StatusComplete
+	}
+
+	if status.UpdateRevision != status.CurrentRevision {
+		return types.StatusProgressing
+	}
+
+	return types.StatusComplete
+}
+
+// statefulSetPartition returns the partition if the rolling update is partitioned, otherwise nil.
+func statefulSetPartition(sts *appsv1.StatefulSet) *int32 {
+	rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.Partition == nil || *rollingUpdate.Partition <= 0 {
+		return nil
+	}
+
+	return rollingUpdate.Partition
+}
+
+// statefulSetMaxUnavailable returns the configured maxUnavailable or the one-at-a-time default.
+func statefulSetMaxUnavailable(sts *appsv1.StatefulSet) string {
+	if sts.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return ""
+	}
+
+	rollingUpdate := sts.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.MaxUnavailable == nil {
+		return defaultStatefulSetMaxUnavailable
+	}
+
+	return formatIntOrPercent(*rollingUpdate.MaxUnavailable)
+}
+
+// podOrdinal extracts the ordinal from a StatefulSet pod name ("<sts>-<ordinal>").
+// Returns -1 if the name does not carry an ordinal.
+func podOrdinal(stsName, podName string) int {
+	suffix, found := strings.CutPrefix(podName, stsName+"-")
+	if !found {
+		return -1
+	}
+
+	ordinal, err := strconv.Atoi(suffix)
+	if err != nil {
+		return -1
+	}
+
+	return ordinal
+}
+
+// orderByOrdinal sorts pod infos by ordinal and inserts placeholders for missing
+// ordinals below desired, so the grid shows the rollout ordinal by ordinal.
+func orderByOrdinal(stsName string, infos []types.PodInfo, desired int32) []types.PodInfo {
+	byOrdinal := make(map[int]types.PodInfo, len(infos))
+	maxOrdinal := int(desired) - 1
+
+	for _, info := range infos {
+		ordinal := podOrdinal(stsName, info.Name)
+		byOrdinal[ordinal] = info
+		maxOrdinal = max(maxOrdinal, ordinal)
+	}
+
+	ordered := make([]types.PodInfo, 0, maxOrdinal+1)
+
+	for ordinal := 0; ordinal <= maxOrdinal; ordinal++ {
+		info, ok := byOrdinal[ordinal]
+		if !ok {
+			if ordinal >= int(desired) {
+				continue // Scaled-down ordinal already gone
+			}
+
+			info = types.PodInfo{Name: fmt.Sprintf("%s-%d", stsName, ordinal), State: types.PodAbsent}
+		}
+
+		ordered = append(ordered, info)
+	}
+
+	// Pods without a parsable ordinal (should not happen) go last
+	if info, ok := byOrdinal[-1]; ok {
+		ordered = append(ordered, info)
+	}
+
+	return ordered
+}
+
+// buildStatefulSetSnapshot constructs a RolloutSnapshot for a StatefulSet.
+// Pods are split into NEW/OLD by their controller-revision-hash label.
+func (c *Controller) buildStatefulSetSnapshot(ctx context.Context, t *rolloutTracker) (*types.RolloutSnapshot, error) {
+	sts, err := c.repo.GetStatefulSet(ctx, t.target.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch statefulset: %w", err)
+	}
+
+	// OnDelete only updates pods as they are deleted by hand, so there is no rollout to follow
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return nil, fmt.Errorf("%w, statefulset uses %s", ErrUnsupportedStrategy, sts.Spec.UpdateStrategy.Type)
+	}
+
+	updateRevision := sts.Status.UpdateRevision
+	if updateRevision == "" {
+		return nil, fmt.Errorf("%w: no update revision reported for statefulset", errSnapshotPending)
+	}
+
+	revision, err := c.repo.GetControllerRevision(ctx, updateRevision)
+	if apierrors.IsNotFound(err) {
+		// The ControllerRevision informer can lag the StatefulSet informer right after an update
+		return nil, fmt.Errorf("%w: update revision '%s'", errSnapshotPending, updateRevision)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch update revision: %w", err)
+	}
+
+	pods, err := c.repo.GetPods(ctx, sts.Spec.Selector, sts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pods: %w", err)
+	}
+
+	now := time.Now()
+	desired := getInt32OrDefault(sts.Spec.Replicas, defaultReplicaCount)
+	isNew := func(pod *corev1.Pod) bool {
+		return pod.Labels[appsv1.ControllerRevisionHashLabelKey] == updateRevision
+	}
+	breakdown := breakdownPods(pods, isNew, sts.Spec.MinReadySeconds, now)
+
+	err = c.attachPodEvents(ctx, breakdown.infos, pods)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pod events: %w", err)
+	}
+
+	rawEvents, err := c.collectEvents(ctx, breakdown.newPods, revision.CreationTimestamp.Time,
+		eventObject{kind: "StatefulSet", obj: sts})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+
+	c.capture(t,
+		sessionObjects("StatefulSet", sts),
+		sessionObjects("ControllerRevision", revision),
+		sessionObjects("Pod", pods...),
+		sessionEvents(rawEvents))
+
+	snapshot := &types.RolloutSnapshot{
+		Kind:           types.KindStatefulSet,
+		WorkloadName:   sts.Name,
+		NewRSName:      updateRevision,
+		Revision:       revision.Revision,
+		StrategyType:   string(sts.Spec.UpdateStrategy.Type),
+		MaxUnavailable: statefulSetMaxUnavailable(sts),
+		Partition:      statefulSetPartition(sts),
+		Desired:        desired,
+		NewRS:          breakdown.newState,
+		OldRS:          breakdown.oldState,
+		Pods:           orderByOrdinal(sts.Name, breakdown.infos, desired),
+		StartTime:      revision.CreationTimestamp.Time,
+		SnapshotTime:   now,
+		Status:         CalculateStatefulSetStatus(sts),
+		StatusMessage:  generationMessage(sts.Status.ObservedGeneration, sts.Generation),
+		Events:         SummarizeEvents(rawEvents, c.config.IgnoreEvents, c.config.SimilarityThreshold),
+		Problems:       DiagnoseContainers(breakdown.newPods),
+		Logs:           c.summarizeFailingLogs(ctx, t, breakdown.newPods),
+	}
+
+	target := snapshot.UpdateTarget()
+	snapshot.NewProgress = calculateProgress(breakdown.newState.Available, target)
+	snapshot.OldProgress = calculateProgress(breakdown.oldState.Available, desired)
+	snapshot.ProgressUpdateTime = latestReadyTime(breakdown.newPods)
+
+	return snapshot, nil
+}
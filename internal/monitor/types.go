@@ -2,20 +2,24 @@
 package monitor
 
 import (
-	"errors"
+	"fmt"
+	"io"
 	"regexp"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
-// ErrProgressDeadlineExceeded indicates rollout failed due to progress deadline
-var ErrProgressDeadlineExceeded = errors.New("progress deadline exceeded")
-
 const (
-	// DefaultPollIntervalSeconds is chosen to balance responsiveness with API load
-	DefaultPollIntervalSeconds = 5
+	// DefaultDebounceMilliseconds coalesces bursts of watch events into a single snapshot
+	DefaultDebounceMilliseconds = 500
+	// DefaultResyncIntervalSeconds re-renders unchanged snapshots so line mode keeps a heartbeat
+	DefaultResyncIntervalSeconds = 30
 	// DefaultMaxEvents limits output while showing most common issues
 	DefaultMaxEvents = 10
 	// DefaultSimilarityThreshold for Drain algorithm.
@@ -34,37 +38,146 @@ const (
 	MinProgressForETA = 0.05
 	// MaxRealisticETAHours caps ETA predictions to prevent absurd estimates
 	MaxRealisticETAHours = 24
+	// DefaultLogTailLines is how many recent log lines are fetched per failing container
+	DefaultLogTailLines = 50
+	// DefaultLogRefreshSeconds limits how often logs of the same container are re-fetched
+	DefaultLogRefreshSeconds = 30
+	// DefaultMaxLogPods caps the failing pods whose logs are fetched, bounding API calls
+	DefaultMaxLogPods = 3
+	// DefaultMaxLogClusters limits output to the most frequent log templates
+	DefaultMaxLogClusters = 5
+	// LogReadinessGraceSeconds is how long a running container may stay unready before its logs are fetched
+	LogReadinessGraceSeconds = 30
+)
+
+// OutputFormat selects how rollout snapshots are presented.
+type OutputFormat string
+
+const (
+	// OutputTUI is the interactive terminal UI (default)
+	OutputTUI OutputFormat = "tui"
+	// OutputLine is timestamped human-readable lines for CI/CD logs
+	OutputLine OutputFormat = "line"
+	// OutputJSONL is one JSON object per snapshot for machine consumption
+	OutputJSONL OutputFormat = "jsonl"
+)
+
+// OutputFormats lists all supported output formats.
+var OutputFormats = []OutputFormat{OutputTUI, OutputLine, OutputJSONL}
+
+// IsInteractive reports whether the output format can prompt the user.
+func (f OutputFormat) IsInteractive() bool {
+	return f == OutputTUI
+}
+
+// HaltPolicy decides how --until-complete treats a halted rollout: a paused deployment,
+// or one whose pods cannot be created (ReplicaFailure condition).
+type HaltPolicy string
+
+const (
+	// HaltWait keeps watching until the rollout resumes or fails (default)
+	HaltWait HaltPolicy = "wait"
+	// HaltFail treats the halted rollout as failed, like an exceeded progress deadline
+	HaltFail HaltPolicy = "fail"
+	// HaltExit stops watching with ErrRolloutHalted, without treating the rollout as failed
+	HaltExit HaltPolicy = "exit"
 )
 
+// HaltPolicies lists all supported halt policies.
+var HaltPolicies = []HaltPolicy{HaltWait, HaltFail, HaltExit}
+
 // Config holds configuration parameters for the rollout monitor.
 // Use DefaultConfig() to obtain sensible defaults, then override as needed.
 type Config struct {
-	PollIntervalSeconds int
-	MaxEvents           int
-	ProgressBarWidth    int
-	SimilarityThreshold float64        // Controls event clustering (0.0-1.0, lower = more aggressive)
-	UntilComplete       bool           // Exit after monitoring one rollout (default: continuous)
-	LineMode            bool           // Use line-based output for CI/CD (default: TUI mode)
-	IgnoreEvents        *regexp.Regexp // Regex to filter out events by "Reason: Message"
+	DebounceMilliseconds  int // Delay after a watch event before building a snapshot
+	ResyncIntervalSeconds int // Re-render interval when nothing changed
+	MaxEvents             int
+	ProgressBarWidth      int
+	SimilarityThreshold   float64        // Controls event clustering (0.0-1.0, lower = more aggressive)
+	UntilComplete         bool           // Exit after monitoring one rollout (default: continuous)
+	RollbackOnFailure     bool           // Roll failed deployments back and watch the rollback (requires UntilComplete)
+	Timeout               time.Duration  // Watch budget applied to ctx (see WithWatchTimeout), reported on timeout
+	Match                 RolloutMatch   // Deployment rollout to wait for, zero matches any rollout
+	OnPaused              HaltPolicy     // How UntilComplete treats a paused deployment
+	OnReplicaFailure      HaltPolicy     // How UntilComplete treats a deployment that cannot create pods
+	Output                OutputFormat   // Presentation format (default: TUI mode)
+	Out                   io.Writer      // Destination of line and JSON Lines output, nil writes to stdout
+	IgnoreEvents          *regexp.Regexp // Regex to filter out events by "Reason: Message"
+	LogTailLines          int64          // Log lines fetched per failing container, 0 disables log collection
+	Record                io.Writer      // Session recording destination (see Replay), nil disables recording
+	MetricsAddr           string         // Serve Prometheus metrics on this address (e.g., ":9090"), empty disables
+	NotifyWebhook         string         // URL to post rollout state transitions to, empty disables notifications
+	NotifyTemplate        string         // Go template of the webhook payload, empty uses DefaultNotifyTemplate
+	NotifyContentType     string         // Webhook Content-Type, empty is JSON for the default template, none otherwise
+	StallTimeout          time.Duration  // No progress for this long reports a progressing rollout as stalled
+	History               *History       // Rollout history to record finished rollouts in, nil disables history
+	ETAModel              ETAModel       // How completion time is estimated (default: linear)
+	MultiTarget           bool           // Several workloads share one view (set from TargetSpec)
 }
 
 // DefaultConfig returns the default configuration
 func DefaultConfig() Config {
 	return Config{
-		PollIntervalSeconds: DefaultPollIntervalSeconds,
-		MaxEvents:           DefaultMaxEvents,
-		ProgressBarWidth:    DefaultProgressBarWidth,
-		SimilarityThreshold: DefaultSimilarityThreshold,
-		UntilComplete:       false, // Default: continuous monitoring
-		IgnoreEvents:        nil,
+		DebounceMilliseconds:  DefaultDebounceMilliseconds,
+		ResyncIntervalSeconds: DefaultResyncIntervalSeconds,
+		MaxEvents:             DefaultMaxEvents,
+		ProgressBarWidth:      DefaultProgressBarWidth,
+		SimilarityThreshold:   DefaultSimilarityThreshold,
+		UntilComplete:         false, // Default: continuous monitoring
+		OnPaused:              HaltWait,
+		OnReplicaFailure:      HaltWait,
+		Output:                OutputTUI,
+		IgnoreEvents:          nil,
+		LogTailLines:          DefaultLogTailLines,
+		ETAModel:              ETALinear,
+		StallTimeout:          DefaultStallSeconds * time.Second,
 	}
 }
 
+// Target identifies the workload whose rollouts are monitored.
+type Target struct {
+	Kind types.WorkloadKind
+	Name string
+}
+
+// TargetSpec selects the workloads to monitor.
+// Explicit targets are always watched; Selector adds every Deployment whose labels match.
+type TargetSpec struct {
+	Targets  []Target
+	Selector labels.Selector // nil when no selector was given
+}
+
+// IsMulti reports whether the spec may resolve to more than one workload.
+func (s TargetSpec) IsMulti() bool {
+	return s.Selector != nil || len(s.Targets) > 1
+}
+
+// Kinds returns the distinct workload kinds the spec can resolve to.
+func (s TargetSpec) Kinds() []types.WorkloadKind {
+	var kinds []types.WorkloadKind
+
+	if s.Selector != nil {
+		kinds = append(kinds, types.KindDeployment)
+	}
+
+	for _, target := range s.Targets {
+		if !slices.Contains(kinds, target.Kind) {
+			kinds = append(kinds, target.Kind)
+		}
+	}
+
+	return kinds
+}
+
 // RolloutResult represents the outcome of a monitoring iteration.
-// Done is true when rollout finished. Failed is true only when deadline exceeded.
+// Done is true when rollout finished. Failed is true when deadline exceeded, or when a
+// halted rollout is treated as failed by its HaltPolicy. Halted is true when a halted
+// rollout ends monitoring with HaltExit. Cause explains a failed or halted result.
 type RolloutResult struct {
 	Done   bool
 	Failed bool
+	Halted bool
+	Cause  error
 }
 
 // isDeploymentComplete checks if deployment rollout is complete
@@ -78,11 +191,18 @@ func isDeploymentFailed(status appsv1.DeploymentStatus) bool {
 	return hasCondition(status, appsv1.DeploymentProgressing, corev1.ConditionFalse, ProgressDeadlineExceeded)
 }
 
-// CalculateRolloutStatus determines rollout status from deployment conditions.
-// Returns Complete if fully available, DeadlineExceeded if failed, otherwise Progressing.
+// CalculateRolloutStatus determines rollout status from deployment spec and conditions.
+// Returns Progressing while the controller has not observed the latest generation,
+// Complete if fully available, DeadlineExceeded if failed, Paused if spec.paused is set,
+// ReplicaFailure if pods cannot be created, otherwise Progressing.
 func CalculateRolloutStatus(deployment *appsv1.Deployment) types.RolloutStatus {
 	status := deployment.Status
 
+	// Until the controller observes the latest spec, conditions describe the previous rollout
+	if status.ObservedGeneration < deployment.Generation {
+		return types.StatusProgressing
+	}
+
 	if isDeploymentComplete(status) {
 		return types.StatusComplete
 	}
@@ -91,9 +211,49 @@ func CalculateRolloutStatus(deployment *appsv1.Deployment) types.RolloutStatus {
 		return types.StatusDeadlineExceeded
 	}
 
+	if deployment.Spec.Paused {
+		return types.StatusPaused
+	}
+
+	if hasCondition(status, appsv1.DeploymentReplicaFailure, corev1.ConditionTrue, "") {
+		return types.StatusReplicaFailure
+	}
+
 	return types.StatusProgressing
 }
 
+// rolloutStatusMessage explains the rollout status: a generation not yet observed by the controller,
+// the ReplicaFailure condition ("Reason: message"), or how to resume a paused deployment.
+// Returns empty string when there is nothing to explain.
+func rolloutStatusMessage(deployment *appsv1.Deployment, status types.RolloutStatus) string {
+	if msg := generationMessage(deployment.Status.ObservedGeneration, deployment.Generation); msg != "" {
+		return msg
+	}
+
+	switch status {
+	case types.StatusPaused:
+		return fmt.Sprintf("resume with 'kubectl rollout resume deployment/%s'", deployment.Name)
+	case types.StatusReplicaFailure:
+		for _, c := range deployment.Status.Conditions {
+			if c.Type == appsv1.DeploymentReplicaFailure {
+				return c.Reason + ": " + strings.Join(strings.Fields(c.Message), " ")
+			}
+		}
+	}
+
+	return ""
+}
+
+// generationMessage reports a workload whose controller has not yet observed its latest generation,
+// as right after `kubectl apply`. Returns empty string once observed.
+func generationMessage(observed, generation int64) string {
+	if observed >= generation {
+		return ""
+	}
+
+	return fmt.Sprintf("waiting for controller to observe generation %d", generation)
+}
+
 // hasCondition checks if a specific condition exists with given type and status.
 // If reason is empty, only type/status checked. If provided, all three must match.
 func hasCondition(
@@ -0,0 +1,310 @@
+package monitor_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "default"
+
+// TestWorkloadSnapshots checks the StatefulSet and DaemonSet snapshots built from fixed cluster states.
+func TestWorkloadSnapshots(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		target     monitor.Target
+		objects    []runtime.Object
+		wantErr    error // Expected error, nil when the rollout is watched
+		wantStatus string
+		wantNew    recordedReplicaState
+		wantNewPct float64 // Expected new progress
+		wantOld    recordedReplicaState
+	}{
+		{
+			name:       "statefulset mid-rollout",
+			target:     monitor.Target{Kind: types.KindStatefulSet, Name: "db"},
+			objects:    statefulSetObjects(nil, 1),
+			wantStatus: "progressing",
+			wantNewPct: 1.0 / 3,
+			wantNew:    recordedReplicaState{Current: 1, Ready: 1, Available: 1},
+			wantOld:    recordedReplicaState{Current: 2, Ready: 2, Available: 2},
+		},
+		{
+			name:       "statefulset partition reached",
+			target:     monitor.Target{Kind: types.KindStatefulSet, Name: "db"},
+			objects:    statefulSetObjects(ptr(int32(2)), 1),
+			wantStatus: "complete",
+			wantNewPct: 1,
+			wantNew:    recordedReplicaState{Current: 1, Ready: 1, Available: 1},
+			wantOld:    recordedReplicaState{Current: 2, Ready: 2, Available: 2},
+		},
+		{
+			name:       "statefulset complete",
+			target:     monitor.Target{Kind: types.KindStatefulSet, Name: "db"},
+			objects:    statefulSetObjects(nil, 3),
+			wantStatus: "complete",
+			wantNewPct: 1,
+			wantNew:    recordedReplicaState{Current: 3, Ready: 3, Available: 3},
+		},
+		{
+			name:    "statefulset on delete",
+			target:  monitor.Target{Kind: types.KindStatefulSet, Name: "db"},
+			objects: onDelete(statefulSetObjects(nil, 1)),
+			wantErr: monitor.ErrUnsupportedStrategy,
+		},
+		{
+			name:       "daemonset mid-rollout",
+			target:     monitor.Target{Kind: types.KindDaemonSet, Name: "agent"},
+			objects:    daemonSetObjects(2, 3),
+			wantStatus: "progressing",
+			wantNewPct: 2.0 / 3,
+			wantNew:    recordedReplicaState{Current: 2, Ready: 2, Available: 2},
+			wantOld:    recordedReplicaState{Current: 1, Ready: 1, Available: 1},
+		},
+		{
+			name:       "daemonset updated pods not yet reported available",
+			target:     monitor.Target{Kind: types.KindDaemonSet, Name: "agent"},
+			objects:    daemonSetObjects(3, 2),
+			wantStatus: "progressing",
+			wantNew:    recordedReplicaState{Current: 3, Ready: 3, Available: 3},
+			wantNewPct: 2.0 / 3,
+		},
+		{
+			name:    "daemonset on delete",
+			target:  monitor.Target{Kind: types.KindDaemonSet, Name: "agent"},
+			objects: onDelete(daemonSetObjects(2, 3)),
+			wantErr: monitor.ErrUnsupportedStrategy,
+		},
+		{
+			name:       "daemonset complete",
+			target:     monitor.Target{Kind: types.KindDaemonSet, Name: "agent"},
+			objects:    daemonSetObjects(3, 3),
+			wantStatus: "complete",
+			wantNewPct: 1,
+			wantNew:    recordedReplicaState{Current: 3, Ready: 3, Available: 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			config := monitor.DefaultConfig()
+			config.Output = monitor.OutputJSONL
+			config.UntilComplete = true
+			config.Timeout = time.Second
+			config.DebounceMilliseconds = 10
+			config.Out = io.Discard
+
+			var recording bytes.Buffer
+
+			config.Record = &recording
+
+			repo := monitor.NewDeploymentRepository(fake.NewClientset(tt.objects...), testNamespace)
+
+			controller, err := monitor.NewWithConfig(repo, monitor.TargetSpec{Targets: []monitor.Target{tt.target}}, config)
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+
+			ctx, cancel := monitor.WithWatchTimeout(t.Context(), config.Timeout)
+			defer cancel()
+
+			err = controller.Run(ctx)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || !errors.Is(err, monitor.ErrInvalidArguments) {
+					t.Fatalf("Run() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if tt.wantStatus == "complete" && err != nil {
+				t.Fatalf("Run() error = %v, want nil", err)
+			}
+
+			if tt.wantStatus != "complete" && !errors.Is(err, monitor.ErrWatchTimeout) {
+				t.Fatalf("Run() error = %v, want %v", err, monitor.ErrWatchTimeout)
+			}
+
+			snapshots := recordedSnapshots(t, &recording)
+			if len(snapshots) == 0 {
+				t.Fatal("no snapshots recorded")
+			}
+
+			last := snapshots[len(snapshots)-1]
+			if last.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", last.Status, tt.wantStatus)
+			}
+
+			if last.New != tt.wantNew {
+				t.Errorf("new = %+v, want %+v", last.New, tt.wantNew)
+			}
+
+			if last.Old != tt.wantOld {
+				t.Errorf("old = %+v, want %+v", last.Old, tt.wantOld)
+			}
+
+			if math.Abs(last.Progress.New-tt.wantNewPct) > 1e-9 {
+				t.Errorf("new progress = %v, want %v", last.Progress.New, tt.wantNewPct)
+			}
+		})
+	}
+}
+
+// statefulSetObjects returns a three-replica StatefulSet with its revisions and pods,
+// the highest updated ordinals running the update revision as a rolling update does.
+func statefulSetObjects(partition *int32, updated int32) []runtime.Object {
+	const replicas = 3
+
+	sts := &appsv1.StatefulSet{
+		ObjectMeta: testMeta("db", "sts-uid", nil),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr(int32(replicas)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: partition},
+			},
+		},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 1,
+			Replicas:           replicas,
+			ReadyReplicas:      replicas,
+			AvailableReplicas:  replicas,
+			UpdatedReplicas:    updated,
+			CurrentReplicas:    replicas - updated,
+			CurrentRevision:    "db-old",
+			UpdateRevision:     "db-new",
+		},
+	}
+
+	if updated == replicas {
+		sts.Status.CurrentRevision = sts.Status.UpdateRevision
+	}
+
+	objects := []runtime.Object{sts, testRevision("db-old", "db-old", 1, sts), testRevision("db-new", "db-new", 2, sts)}
+
+	for ordinal := range int32(replicas) {
+		hash := "db-old"
+		if ordinal >= replicas-updated {
+			hash = "db-new"
+		}
+
+		objects = append(objects, testPod(fmt.Sprintf("%s-%d", sts.Name, ordinal), "", hash, sts))
+	}
+
+	return objects
+}
+
+// daemonSetObjects returns a DaemonSet scheduled to three nodes with its revisions and ready pods,
+// the first updated nodes running the newest revision. Status reports available pods.
+func daemonSetObjects(updated, available int32) []runtime.Object {
+	const nodes = 3
+
+	ds := &appsv1.DaemonSet{
+		ObjectMeta: testMeta("agent", "ds-uid", nil),
+		Spec: appsv1.DaemonSetSpec{
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType},
+		},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration:     1,
+			DesiredNumberScheduled: nodes,
+			CurrentNumberScheduled: nodes,
+			NumberReady:            nodes,
+			NumberAvailable:        available,
+			UpdatedNumberScheduled: updated,
+		},
+	}
+
+	objects := []runtime.Object{ds, testRevision("agent-1", "hash-old", 1, ds), testRevision("agent-2", "hash-new", 2, ds)}
+
+	for node := range int32(nodes) {
+		hash := "hash-old"
+		if node < updated {
+			hash = "hash-new"
+		}
+
+		objects = append(objects, testPod(fmt.Sprintf("agent-%d", node), fmt.Sprintf("node-%d", node), hash, ds))
+	}
+
+	return objects
+}
+
+// onDelete switches the workload, the first of objects, to the OnDelete update strategy.
+func onDelete(objects []runtime.Object) []runtime.Object {
+	switch workload := objects[0].(type) {
+	case *appsv1.StatefulSet:
+		workload.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+	case *appsv1.DaemonSet:
+		workload.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+	}
+
+	return objects
+}
+
+// testMeta returns object metadata in the test namespace, controlled by owner if set.
+func testMeta(name string, uid apitypes.UID, owner metav1.Object) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         testNamespace,
+		UID:               uid,
+		Generation:        1,
+		CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+	}
+
+	if owner != nil {
+		meta.OwnerReferences = []metav1.OwnerReference{{
+			Name:       owner.GetName(),
+			UID:        owner.GetUID(),
+			Controller: ptr(true),
+		}}
+	}
+
+	return meta
+}
+
+// testRevision returns a ControllerRevision of owner carrying the given hash label.
+func testRevision(name, hash string, revision int64, owner metav1.Object) *appsv1.ControllerRevision {
+	meta := testMeta(name, apitypes.UID(name+"-uid"), owner)
+	meta.Labels = map[string]string{"app": owner.GetName(), appsv1.ControllerRevisionHashLabelKey: hash}
+
+	return &appsv1.ControllerRevision{ObjectMeta: meta, Revision: revision}
+}
+
+// testPod returns a ready pod of owner on node running the revision with the given hash.
+func testPod(name, node, hash string, owner metav1.Object) *corev1.Pod {
+	meta := testMeta(name, apitypes.UID(name+"-uid"), owner)
+	meta.Labels = map[string]string{"app": owner.GetName(), appsv1.ControllerRevisionHashLabelKey: hash}
+
+	return &corev1.Pod{
+		ObjectMeta: meta,
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{
+				Type:               corev1.PodReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: meta.CreationTimestamp,
+			}},
+		},
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,375 @@
+// Package simulator runs synthetic rollouts against the fake clientset from k8s.io/client-go/kubernetes/fake.
+// A minimal deployment controller and kubelet advance a rollout step by step, following the RollingUpdate
+// strategy, and report pod failures and events the way a real cluster does. The monitor reads the fake
+// clientset through its regular DeploymentRepository, so simulated rollouts render exactly like real ones.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// Scenario selects how pods of the new revision behave.
+type Scenario string
+
+const (
+	// ScenarioSuccess starts new pods healthy, so the rollout completes
+	ScenarioSuccess Scenario = "success"
+	// ScenarioDeadline keeps new pods failing readiness until the progress deadline is exceeded
+	ScenarioDeadline Scenario = "deadline"
+	// ScenarioCrashLoop crashes new containers on start, leaving them in CrashLoopBackOff
+	ScenarioCrashLoop Scenario = "crashloop"
+	// ScenarioImagePull points the rollout at a missing image tag, leaving new pods in ImagePullBackOff
+	ScenarioImagePull Scenario = "imagepull"
+	// ScenarioQuota rejects new pods for exceeding the namespace resource quota (ReplicaFailure)
+	ScenarioQuota Scenario = "quota"
+)
+
+// Scenarios lists all supported scenarios.
+var Scenarios = []Scenario{ScenarioSuccess, ScenarioDeadline, ScenarioCrashLoop, ScenarioImagePull, ScenarioQuota}
+
+const (
+	// DefaultReplicas is the simulated deployment's replica count
+	DefaultReplicas = 4
+	// DefaultStep is the interval between simulated controller and kubelet steps
+	DefaultStep = time.Second
+	// DefaultStartAfter is how many steps the previous revision runs before the rollout starts
+	DefaultStartAfter = 0
+	// DefaultProgressDeadline is shorter than the K8s default to keep simulated failures quick
+	DefaultProgressDeadline = 30 * time.Second
+
+	containerName = "web"
+	oldImage      = "registry.example.com/shop/web:1.4.1"
+	newImage      = "registry.example.com/shop/web:1.4.2"
+	missingImage  = "registry.example.com/shop/web:1.4.2-rc1"
+	simNodes      = 3
+)
+
+// Options configures the simulated cluster.
+type Options struct {
+	Namespace        string
+	Name             string // Deployment name
+	Replicas         int32
+	Scenario         Scenario
+	Step             time.Duration // Interval between simulation steps
+	StartAfter       int           // Steps before the deployment is updated to the new image, 0 updates it on creation
+	ProgressDeadline time.Duration
+	MaxSurge         *intstr.IntOrString // RollingUpdate maxSurge, nil uses the K8s default of 25%
+	MaxUnavailable   *intstr.IntOrString // RollingUpdate maxUnavailable, nil uses the K8s default of 25%
+}
+
+// Validate checks that the options describe a deployment the API server would accept.
+func (o Options) Validate() error {
+	if !slices.Contains(Scenarios, o.Scenario) {
+		return fmt.Errorf("unknown scenario '%s'", o.Scenario)
+	}
+
+	if o.Replicas < 1 {
+		return errors.New("replicas must be at least 1")
+	}
+
+	if o.Step <= 0 {
+		return errors.New("step must be positive")
+	}
+
+	if o.StartAfter < 0 {
+		return errors.New("start delay must not be negative")
+	}
+
+	surge, unavailable, err := scaledLimits(o.MaxSurge, o.MaxUnavailable, o.Replicas)
+	if err != nil {
+		return err
+	}
+
+	if surge < 0 || unavailable < 0 {
+		return errors.New("max surge and max unavailable must not be negative")
+	}
+
+	if isZero(o.MaxSurge) && isZero(o.MaxUnavailable) {
+		return errors.New("max surge and max unavailable must not both be zero")
+	}
+
+	return nil
+}
+
+// DefaultOptions returns options for a small deployment rolling out successfully.
+func DefaultOptions() Options {
+	return Options{
+		Namespace:        "default",
+		Name:             "web",
+		Replicas:         DefaultReplicas,
+		Scenario:         ScenarioSuccess,
+		Step:             DefaultStep,
+		StartAfter:       DefaultStartAfter,
+		ProgressDeadline: DefaultProgressDeadline,
+	}
+}
+
+// Cluster is a simulated namespace holding one deployment, stored in a fake clientset.
+// State is only modified by Run; the clientset is safe to read concurrently.
+type Cluster struct {
+	opts      Options
+	clientset *fake.Clientset
+
+	uids        int
+	replicaSets []*appsv1.ReplicaSet // Ascending revision, the last one is the new ReplicaSet
+	pods        []*simPod
+	events      map[string]*corev1.Event // Keyed by involved object and message, repeats bump the count
+
+	steps         int
+	progressed    bool      // The rollout advanced in the current step
+	lastProgress  time.Time // Progress deadline is measured from here
+	failed        bool      // Progress deadline exceeded for the current revision
+	createFailure string    // Why the last pod of the new ReplicaSet could not be created
+}
+
+// simPod is a pod with its simulated lifecycle state.
+type simPod struct {
+	pod      *corev1.Pod
+	age      int  // Steps since creation
+	deleting bool // Terminating, removed on the next step
+}
+
+// New creates a simulated cluster with the deployment fully rolled out on its previous revision.
+func New(opts Options) (*Cluster, error) {
+	err := opts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Cluster{
+		opts:      opts,
+		clientset: fake.NewClientset(),
+		events:    make(map[string]*corev1.Event),
+	}
+
+	c.clientset.PrependReactor("update", "deployments", c.bumpGeneration)
+
+	err = c.seed(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed simulated cluster: %w", err)
+	}
+
+	return c, nil
+}
+
+// Clientset returns the fake clientset holding the simulated objects.
+func (c *Cluster) Clientset() kubernetes.Interface { return c.clientset }
+
+// Source returns the monitor data source for the simulated namespace.
+func (c *Cluster) Source() monitor.RolloutSource {
+	return &source{
+		DeploymentRepository: monitor.NewDeploymentRepository(c.clientset, c.opts.Namespace),
+		cluster:              c,
+	}
+}
+
+// Run advances the simulation every Step until ctx is cancelled.
+func (c *Cluster) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.opts.Step)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			err := c.step(ctx)
+			if err != nil {
+				return fmt.Errorf("simulation step %d failed: %w", c.steps, err)
+			}
+		}
+	}
+}
+
+// bumpGeneration mimics the API server: spec changes increment metadata.generation.
+func (c *Cluster) bumpGeneration(action k8stesting.Action) (bool, runtime.Object, error) {
+	update, ok := action.(k8stesting.UpdateAction)
+	if !ok || update.GetSubresource() != "" {
+		return false, nil, nil
+	}
+
+	deployment, ok := update.GetObject().(*appsv1.Deployment)
+	if !ok {
+		return false, nil, nil
+	}
+
+	existing, err := c.clientset.Tracker().Get(update.GetResource(), deployment.Namespace, deployment.Name)
+	if err != nil {
+		return false, nil, nil //nolint:nilerr // let the default reactor report the missing object
+	}
+
+	if old, ok := existing.(*appsv1.Deployment); ok && !apiequality.Semantic.DeepEqual(old.Spec, deployment.Spec) {
+		deployment.Generation = old.Generation + 1
+	}
+
+	return false, nil, nil
+}
+
+// seed creates the deployment with every pod of its previous revision available.
+func (c *Cluster) seed(ctx context.Context) error {
+	labels := map[string]string{"app": c.opts.Name}
+	replicas := c.opts.Replicas
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: c.objectMeta(c.opts.Name, labels),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: containerName, Image: oldImage}},
+				},
+			},
+			Strategy: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxSurge:       c.opts.MaxSurge,
+					MaxUnavailable: c.opts.MaxUnavailable,
+				},
+			},
+		},
+	}
+	deployment.Generation = 1
+
+	deployment, err := c.clientset.AppsV1().Deployments(c.opts.Namespace).Create(ctx, deployment, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	rs, err := c.createReplicaSet(ctx, deployment, 1)
+	if err != nil {
+		return err
+	}
+
+	rs.Spec.Replicas = &replicas
+
+	for range replicas {
+		pod, err := c.createPod(ctx, rs)
+		if err != nil {
+			return err
+		}
+
+		pod.age = readyAge
+		pod.pod.Spec.NodeName = fmt.Sprintf("node-%d", c.uids%simNodes+1)
+		startContainer(pod, true)
+
+		err = c.updatePod(ctx, pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.lastProgress = time.Now()
+
+	err = c.updateStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if c.opts.StartAfter == 0 {
+		return c.startRollout(ctx)
+	}
+
+	return nil
+}
+
+// startRollout updates the deployment to the new image.
+func (c *Cluster) startRollout(ctx context.Context) error {
+	deployments := c.clientset.AppsV1().Deployments(c.opts.Namespace)
+
+	deployment, err := deployments.Get(ctx, c.opts.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	deployment.Spec.Template.Spec.Containers[0].Image = c.rolloutImage()
+
+	_, err = deployments.Update(ctx, deployment, metav1.UpdateOptions{})
+
+	return err
+}
+
+// step runs one round of the deployment controller, the ReplicaSet controller and the kubelet.
+func (c *Cluster) step(ctx context.Context) error {
+	c.steps++
+	c.progressed = false
+
+	if c.steps == c.opts.StartAfter {
+		err := c.startRollout(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	deployment, err := c.clientset.AppsV1().Deployments(c.opts.Namespace).Get(ctx, c.opts.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	err = c.syncRevision(ctx, deployment)
+	if err != nil {
+		return err
+	}
+
+	err = c.runKubelet(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !deployment.Spec.Paused {
+		err = c.scale(ctx, deployment)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = c.syncPods(ctx)
+	if err != nil {
+		return err
+	}
+
+	return c.updateStatus(ctx)
+}
+
+// objectMeta returns metadata for a new simulated object.
+func (c *Cluster) objectMeta(name string, labels map[string]string) metav1.ObjectMeta {
+	c.uids++
+
+	return metav1.ObjectMeta{
+		Name:              name,
+		Namespace:         c.opts.Namespace,
+		UID:               uid(c.uids),
+		Labels:            labels,
+		CreationTimestamp: metav1.Now(),
+	}
+}
+
+// rolloutImage returns the image the deployment is updated to.
+func (c *Cluster) rolloutImage() string {
+	if c.opts.Scenario == ScenarioImagePull {
+		return missingImage
+	}
+
+	return newImage
+}
+
+// newReplicaSet returns the ReplicaSet of the current revision.
+func (c *Cluster) newReplicaSet() *appsv1.ReplicaSet {
+	return c.replicaSets[len(c.replicaSets)-1]
+}
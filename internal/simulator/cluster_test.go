@@ -0,0 +1,324 @@
+package simulator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// maxTestSteps bounds simulated rollouts, far more than any test case needs.
+const maxTestSteps = 100
+
+// TestRollingUpdate steps rollouts to completion and checks the RollingUpdate limits after every step.
+func TestRollingUpdate(t *testing.T) {
+	limit := func(value intstr.IntOrString) *intstr.IntOrString { return &value }
+
+	tests := []struct {
+		name           string
+		replicas       int32
+		maxSurge       *intstr.IntOrString
+		maxUnavailable *intstr.IntOrString
+		wantSurge      int32 // Pods allowed above the desired count
+		wantUnavail    int32 // Pods allowed below the desired count
+	}{
+		{name: "defaults", replicas: 4, wantSurge: 1, wantUnavail: 1},
+		{
+			name: "surge only", replicas: 5,
+			maxSurge: limit(intstr.FromInt32(1)), maxUnavailable: limit(intstr.FromInt32(0)),
+			wantSurge: 1,
+		},
+		{
+			name: "unavailable only", replicas: 5,
+			maxSurge: limit(intstr.FromInt32(0)), maxUnavailable: limit(intstr.FromInt32(2)),
+			wantUnavail: 2,
+		},
+		{
+			name: "full surge", replicas: 3,
+			maxSurge: limit(intstr.FromString("100%")), maxUnavailable: limit(intstr.FromInt32(0)),
+			wantSurge: 3,
+		},
+		{name: "single replica", replicas: 1, wantSurge: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Replicas = tt.replicas
+			opts.MaxSurge = tt.maxSurge
+			opts.MaxUnavailable = tt.maxUnavailable
+
+			c := newTestCluster(t, opts)
+
+			for range maxTestSteps {
+				step(t, c)
+
+				pods, available := c.countPods()
+				if pods > tt.replicas+tt.wantSurge {
+					t.Fatalf("step %d: %d pods, want at most %d", c.steps, pods, tt.replicas+tt.wantSurge)
+				}
+
+				if available < tt.replicas-tt.wantUnavail {
+					t.Fatalf("step %d: %d available pods, want at least %d", c.steps, available, tt.replicas-tt.wantUnavail)
+				}
+
+				if progressing(t, c).Reason == monitor.NewReplicaSetAvailable {
+					break
+				}
+			}
+
+			if reason := progressing(t, c).Reason; reason != monitor.NewReplicaSetAvailable {
+				t.Fatalf("rollout not complete after %d steps, Progressing reason %q", c.steps, reason)
+			}
+
+			for _, rs := range c.replicaSets[:len(c.replicaSets)-1] {
+				if replicas(rs) != 0 {
+					t.Errorf("old ReplicaSet %s has %d replicas, want 0", rs.Name, replicas(rs))
+				}
+			}
+
+			for _, pod := range c.pods {
+				if !pod.deleting && !metav1.IsControlledBy(pod.pod, c.newReplicaSet()) {
+					t.Errorf("pod %s does not belong to the new ReplicaSet", pod.pod.Name)
+				}
+			}
+		})
+	}
+}
+
+// TestFailureScenarios checks how each failure scenario shows up in pods and deployment conditions.
+func TestFailureScenarios(t *testing.T) {
+	tests := []struct {
+		scenario Scenario
+		// check reports what is missing from the cluster state, empty once the failure shows
+		check func(c *Cluster, deployment *appsv1.Deployment) string
+	}{
+		{
+			scenario: ScenarioDeadline,
+			check: func(_ *Cluster, deployment *appsv1.Deployment) string {
+				return wantCondition(deployment, appsv1.DeploymentProgressing, monitor.ProgressDeadlineExceeded)
+			},
+		},
+		{
+			scenario: ScenarioCrashLoop,
+			check: func(c *Cluster, _ *appsv1.Deployment) string {
+				return c.wantWaitingPod("CrashLoopBackOff", true)
+			},
+		},
+		{
+			scenario: ScenarioImagePull,
+			check: func(c *Cluster, _ *appsv1.Deployment) string {
+				return c.wantWaitingPod("ImagePullBackOff", false)
+			},
+		},
+		{
+			scenario: ScenarioQuota,
+			check: func(_ *Cluster, deployment *appsv1.Deployment) string {
+				return wantCondition(deployment, appsv1.DeploymentReplicaFailure, "FailedCreate")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.scenario), func(t *testing.T) {
+			opts := DefaultOptions()
+			opts.Scenario = tt.scenario
+			opts.ProgressDeadline = time.Nanosecond // Any step without progress exceeds it
+
+			c := newTestCluster(t, opts)
+
+			var missing string
+
+			for range maxTestSteps {
+				step(t, c)
+
+				missing = tt.check(c, deployment(t, c))
+				if missing == "" {
+					break
+				}
+			}
+
+			if missing != "" {
+				t.Fatalf("after %d steps: %s", c.steps, missing)
+			}
+
+			// The previous revision keeps serving within maxUnavailable
+			_, available := c.countPods()
+			if available < opts.Replicas-1 {
+				t.Errorf("%d available pods, want at least %d", available, opts.Replicas-1)
+			}
+
+			if reason := progressing(t, c).Reason; reason == monitor.NewReplicaSetAvailable {
+				t.Errorf("failing rollout reported complete")
+			}
+		})
+	}
+}
+
+// TestRollbackRevivesReplicaSet checks that returning to the previous template scales its
+// ReplicaSet back up under a new revision instead of creating another one.
+func TestRollbackRevivesReplicaSet(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Scenario = ScenarioCrashLoop
+
+	c := newTestCluster(t, opts)
+	previous := c.replicaSets[0]
+
+	for range 5 {
+		step(t, c)
+	}
+
+	d := deployment(t, c)
+	d.Spec.Template.Spec.Containers[0].Image = oldImage
+
+	_, err := c.clientset.AppsV1().Deployments(opts.Namespace).Update(t.Context(), d, metav1.UpdateOptions{})
+	if err != nil {
+		t.Fatalf("failed to roll back deployment: %v", err)
+	}
+
+	for range maxTestSteps {
+		step(t, c)
+
+		if progressing(t, c).Reason == monitor.NewReplicaSetAvailable {
+			break
+		}
+	}
+
+	if len(c.replicaSets) != 2 || c.newReplicaSet() != previous {
+		t.Fatalf("new ReplicaSet = %s of %d, want revived %s", c.newReplicaSet().Name, len(c.replicaSets), previous.Name)
+	}
+
+	if revision := previous.Annotations[monitor.RevisionAnnotation]; revision != "3" {
+		t.Errorf("revived ReplicaSet revision = %s, want 3", revision)
+	}
+
+	if reason := progressing(t, c).Reason; reason != monitor.NewReplicaSetAvailable {
+		t.Errorf("rollback not complete after %d steps, Progressing reason %q", c.steps, reason)
+	}
+}
+
+// TestOptionsValidate checks that options the API server would reject are refused.
+func TestOptionsValidate(t *testing.T) {
+	zero := intstr.FromInt32(0)
+	negative := intstr.FromInt32(-1)
+
+	tests := []struct {
+		name    string
+		modify  func(*Options)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(*Options) {}},
+		{name: "unknown scenario", modify: func(o *Options) { o.Scenario = "meteor" }, wantErr: true},
+		{name: "no replicas", modify: func(o *Options) { o.Replicas = 0 }, wantErr: true},
+		{name: "no step", modify: func(o *Options) { o.Step = 0 }, wantErr: true},
+		{name: "negative start delay", modify: func(o *Options) { o.StartAfter = -1 }, wantErr: true},
+		{name: "negative surge", modify: func(o *Options) { o.MaxSurge = &negative }, wantErr: true},
+		{
+			name:    "surge and unavailable both zero",
+			modify:  func(o *Options) { o.MaxSurge, o.MaxUnavailable = &zero, &zero },
+			wantErr: true,
+		},
+		{name: "zero surge", modify: func(o *Options) { o.MaxSurge = &zero }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tt.modify(&opts)
+
+			err := opts.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestCluster creates a simulated cluster, failing the test on error.
+func newTestCluster(t *testing.T, opts Options) *Cluster {
+	t.Helper()
+
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return c
+}
+
+// step runs one simulation step, failing the test on error.
+func step(t *testing.T, c *Cluster) {
+	t.Helper()
+
+	err := c.step(t.Context())
+	if err != nil {
+		t.Fatalf("step %d failed: %v", c.steps, err)
+	}
+}
+
+// deployment reads the simulated deployment.
+func deployment(t *testing.T, c *Cluster) *appsv1.Deployment {
+	t.Helper()
+
+	d, err := c.clientset.AppsV1().Deployments(c.opts.Namespace).Get(t.Context(), c.opts.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get deployment: %v", err)
+	}
+
+	return d
+}
+
+// progressing returns the deployment's Progressing condition, zero if it has none.
+func progressing(t *testing.T, c *Cluster) appsv1.DeploymentCondition {
+	t.Helper()
+
+	for _, condition := range deployment(t, c).Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing {
+			return condition
+		}
+	}
+
+	return appsv1.DeploymentCondition{}
+}
+
+// countPods counts non-terminating pods and those of them that are ready.
+func (c *Cluster) countPods() (int32, int32) {
+	var pods int32
+
+	for _, pod := range c.pods {
+		if !pod.deleting {
+			pods++
+		}
+	}
+
+	return pods, c.availablePods(nil)
+}
+
+// wantCondition describes a deployment condition that is not true with the given reason, empty if it is.
+func wantCondition(deployment *appsv1.Deployment, condType appsv1.DeploymentConditionType, reason string) string {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == condType && condition.Reason == reason {
+			return ""
+		}
+	}
+
+	return "no " + string(condType) + " condition with reason " + reason
+}
+
+// wantWaitingPod describes a new pod missing from the cluster: one whose container waits
+// with reason, after a restart if restarted is set. Empty if such a pod exists.
+func (c *Cluster) wantWaitingPod(reason string, restarted bool) string {
+	for _, pod := range c.activePods(c.newReplicaSet()) {
+		for _, status := range pod.pod.Status.ContainerStatuses {
+			waiting := status.State.Waiting
+			if waiting != nil && waiting.Reason == reason && (!restarted || status.RestartCount > 0) {
+				return ""
+			}
+		}
+	}
+
+	return "no new pod waiting with " + reason
+}
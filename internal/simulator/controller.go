@@ -0,0 +1,517 @@
+package simulator
+
+// This file contains the simulated deployment and ReplicaSet controllers.
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+// defaultRollingUpdate is the K8s default for maxSurge and maxUnavailable.
+var defaultRollingUpdate = intstr.FromString("25%")
+
+// uid returns a unique object UID.
+func uid(n int) k8stypes.UID {
+	return k8stypes.UID(fmt.Sprintf("00000000-0000-0000-0000-%012d", n))
+}
+
+// templateHash derives the pod-template-hash of an image, like the deployment controller does from the template.
+func templateHash(image string) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(image))
+
+	return rand.SafeEncodeString(strconv.FormatUint(uint64(hasher.Sum32()), 10))
+}
+
+// replicaSetImage returns the image a ReplicaSet runs.
+func replicaSetImage(rs *appsv1.ReplicaSet) string {
+	return rs.Spec.Template.Spec.Containers[0].Image
+}
+
+// replicas returns the desired replica count of a ReplicaSet.
+func replicas(rs *appsv1.ReplicaSet) int32 {
+	if rs.Spec.Replicas == nil {
+		return 0
+	}
+
+	return *rs.Spec.Replicas
+}
+
+// syncRevision makes the ReplicaSet running the deployment's template the new one, creating it
+// if needed. Returning to an older template (a rollback) revives its ReplicaSet with a new revision.
+func (c *Cluster) syncRevision(ctx context.Context, deployment *appsv1.Deployment) error {
+	image := deployment.Spec.Template.Spec.Containers[0].Image
+	newest := c.newReplicaSet()
+	revision := int64(len(c.replicaSets) + 1)
+
+	if replicaSetImage(newest) == image {
+		return nil
+	}
+
+	index := slices.IndexFunc(c.replicaSets, func(rs *appsv1.ReplicaSet) bool { return replicaSetImage(rs) == image })
+	if index < 0 {
+		rs, err := c.createReplicaSet(ctx, deployment, revision)
+		if err != nil {
+			return err
+		}
+
+		c.startRevision(rs)
+
+		return nil
+	}
+
+	rs := c.replicaSets[index]
+	c.replicaSets = append(slices.Delete(c.replicaSets, index, index+1), rs)
+	rs.Annotations[monitor.RevisionAnnotation] = strconv.FormatInt(revision, 10)
+	c.startRevision(rs)
+
+	_, err := c.clientset.AppsV1().ReplicaSets(c.opts.Namespace).Update(ctx, rs, metav1.UpdateOptions{})
+
+	return err
+}
+
+// startRevision resets progress tracking for a new rollout.
+func (c *Cluster) startRevision(*appsv1.ReplicaSet) {
+	c.progressed = true
+	c.failed = false
+	c.createFailure = ""
+	c.lastProgress = time.Now()
+}
+
+// createReplicaSet creates a ReplicaSet for the deployment's current template with zero replicas.
+func (c *Cluster) createReplicaSet(
+	ctx context.Context,
+	deployment *appsv1.Deployment,
+	revision int64,
+) (*appsv1.ReplicaSet, error) {
+	template := deployment.Spec.Template.DeepCopy()
+	hash := templateHash(template.Spec.Containers[0].Image)
+
+	labels := map[string]string{appsv1.DefaultDeploymentUniqueLabelKey: hash}
+	for k, v := range template.Labels {
+		labels[k] = v
+	}
+
+	template.Labels = labels
+	selector := deployment.Spec.Selector.DeepCopy()
+	selector.MatchLabels[appsv1.DefaultDeploymentUniqueLabelKey] = hash
+	zero := int32(0)
+
+	rs := &appsv1.ReplicaSet{
+		ObjectMeta: c.objectMeta(deployment.Name+"-"+hash, labels),
+		Spec: appsv1.ReplicaSetSpec{
+			Replicas: &zero,
+			Selector: selector,
+			Template: *template,
+		},
+	}
+	rs.Annotations = map[string]string{monitor.RevisionAnnotation: strconv.FormatInt(revision, 10)}
+	rs.OwnerReferences = []metav1.OwnerReference{controllerRef("Deployment", deployment)}
+
+	rs, err := c.clientset.AppsV1().ReplicaSets(c.opts.Namespace).Create(ctx, rs, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	c.replicaSets = append(c.replicaSets, rs)
+
+	return rs, nil
+}
+
+// controllerRef returns an owner reference marking owner as the controller.
+func controllerRef(kind string, owner metav1.Object) metav1.OwnerReference {
+	isController := true
+
+	return metav1.OwnerReference{
+		APIVersion: appsv1.SchemeGroupVersion.String(),
+		Kind:       kind,
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+		Controller: &isController,
+	}
+}
+
+// rollingUpdateLimits resolves maxSurge and maxUnavailable to pod counts, like the deployment controller.
+func rollingUpdateLimits(deployment *appsv1.Deployment) (int32, int32) {
+	var maxSurge, maxUnavailable *intstr.IntOrString
+	if ru := deployment.Spec.Strategy.RollingUpdate; ru != nil {
+		maxSurge, maxUnavailable = ru.MaxSurge, ru.MaxUnavailable
+	}
+
+	surge, unavailable, _ := scaledLimits(maxSurge, maxUnavailable, *deployment.Spec.Replicas)
+
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1 // Both zero would deadlock the rollout, the API server rejects it
+	}
+
+	return surge, unavailable
+}
+
+// scaledLimits resolves maxSurge (rounded up) and maxUnavailable (rounded down) against the
+// desired replica count. Nil values default to 25%.
+func scaledLimits(maxSurge, maxUnavailable *intstr.IntOrString, desired int32) (int32, int32, error) {
+	if maxSurge == nil {
+		maxSurge = &defaultRollingUpdate
+	}
+
+	if maxUnavailable == nil {
+		maxUnavailable = &defaultRollingUpdate
+	}
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(maxSurge, int(desired), true)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid max surge: %w", err)
+	}
+
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, int(desired), false)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid max unavailable: %w", err)
+	}
+
+	return int32(surge), int32(unavailable), nil //nolint:gosec // bounded by replica count
+}
+
+// isZero reports whether an explicit maxSurge or maxUnavailable resolves to zero pods.
+func isZero(value *intstr.IntOrString) bool {
+	return value != nil && (value.String() == "0" || value.String() == "0%")
+}
+
+// scale runs one rolling update round: scale the new ReplicaSet up within maxSurge, then scale old
+// ReplicaSets down as far as maxUnavailable allows, removing their unavailable pods first.
+func (c *Cluster) scale(ctx context.Context, deployment *appsv1.Deployment) error {
+	desired := *deployment.Spec.Replicas
+	surge, unavailable := rollingUpdateLimits(deployment)
+	minAvailable := desired - unavailable
+	newRS := c.newReplicaSet()
+	olds := c.replicaSets[:len(c.replicaSets)-1]
+
+	var oldTotal int32
+	for _, rs := range olds {
+		oldTotal += replicas(rs)
+	}
+
+	if want := min(desired, desired+surge-oldTotal); want > replicas(newRS) {
+		err := c.scaleReplicaSet(ctx, deployment, newRS, want)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Unavailable old pods do not count towards availability, removing them never hurts
+	newUnavailable := max(0, replicas(newRS)-c.availablePods(newRS))
+	cleanup := oldTotal + replicas(newRS) - minAvailable - newUnavailable
+
+	err := c.scaleDown(ctx, deployment, olds, cleanup, func(rs *appsv1.ReplicaSet) int32 {
+		return max(0, replicas(rs)-c.availablePods(rs))
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.scaleDown(ctx, deployment, olds, c.availablePods(nil)-minAvailable, replicas)
+}
+
+// scaleDown removes up to limit replicas from ReplicaSets, at most removable(rs) from each.
+func (c *Cluster) scaleDown(
+	ctx context.Context,
+	deployment *appsv1.Deployment,
+	replicaSets []*appsv1.ReplicaSet,
+	limit int32,
+	removable func(*appsv1.ReplicaSet) int32,
+) error {
+	for _, rs := range replicaSets {
+		if limit <= 0 {
+			break
+		}
+
+		if n := min(removable(rs), limit); n > 0 {
+			err := c.scaleReplicaSet(ctx, deployment, rs, replicas(rs)-n)
+			if err != nil {
+				return err
+			}
+
+			limit -= n
+		}
+	}
+
+	return nil
+}
+
+// scaleReplicaSet sets a ReplicaSet's replica count and reports it on the deployment.
+func (c *Cluster) scaleReplicaSet(
+	ctx context.Context,
+	deployment *appsv1.Deployment,
+	rs *appsv1.ReplicaSet,
+	count int32,
+) error {
+	message := fmt.Sprintf("Scaled up replica set %s from %d to %d", rs.Name, replicas(rs), count)
+	if count < replicas(rs) {
+		message = fmt.Sprintf("Scaled down replica set %s from %d to %d", rs.Name, replicas(rs), count)
+	}
+
+	rs.Spec.Replicas = &count
+	c.progressed = true
+
+	_, err := c.clientset.AppsV1().ReplicaSets(c.opts.Namespace).Update(ctx, rs, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	return c.recordEvent(ctx, "Deployment", deployment, "deployment-controller",
+		corev1.EventTypeNormal, "ScalingReplicaSet", message)
+}
+
+// syncPods creates and deletes pods to match each ReplicaSet's replica count.
+// Unready pods are deleted first, then the youngest ones. Terminating pods are removed a step later.
+func (c *Cluster) syncPods(ctx context.Context) error {
+	for _, rs := range c.replicaSets {
+		active := c.activePods(rs)
+
+		for range max(0, int(replicas(rs))-len(active)) {
+			if c.exceedsQuota(rs) {
+				// Like the ReplicaSet controller, stop the batch at the first rejected pod
+				err := c.rejectPod(ctx, rs)
+				if err != nil {
+					return err
+				}
+
+				break
+			}
+
+			_, err := c.createPod(ctx, rs)
+			if err != nil {
+				return err
+			}
+		}
+
+		excess := len(active) - int(replicas(rs))
+		if excess <= 0 {
+			continue
+		}
+
+		slices.SortStableFunc(active, func(a, b *simPod) int {
+			if a.ready() != b.ready() {
+				if a.ready() {
+					return 1
+				}
+
+				return -1
+			}
+
+			return a.age - b.age
+		})
+
+		for _, pod := range active[:excess] {
+			err := c.deletePod(ctx, rs, pod)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// activePods returns non-terminating pods of a ReplicaSet.
+func (c *Cluster) activePods(rs *appsv1.ReplicaSet) []*simPod {
+	var result []*simPod
+
+	for _, pod := range c.pods {
+		if !pod.deleting && metav1.IsControlledBy(pod.pod, rs) {
+			result = append(result, pod)
+		}
+	}
+
+	return result
+}
+
+// availablePods counts ready, non-terminating pods of a ReplicaSet, or of all ReplicaSets if rs is nil.
+func (c *Cluster) availablePods(rs *appsv1.ReplicaSet) int32 {
+	var count int32
+
+	for _, pod := range c.pods {
+		if !pod.deleting && pod.ready() && (rs == nil || metav1.IsControlledBy(pod.pod, rs)) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// updateStatus reports ReplicaSet and deployment status, including rollout conditions.
+func (c *Cluster) updateStatus(ctx context.Context) error {
+	var total, ready, available int32
+
+	for _, rs := range c.replicaSets {
+		status := appsv1.ReplicaSetStatus{ObservedGeneration: rs.Generation}
+
+		for _, pod := range c.activePods(rs) {
+			status.Replicas++
+
+			if pod.ready() {
+				status.ReadyReplicas++
+				status.AvailableReplicas++
+			}
+		}
+
+		total += status.Replicas
+		ready += status.ReadyReplicas
+		available += status.AvailableReplicas
+
+		if apiequality.Semantic.DeepEqual(rs.Status, status) {
+			continue
+		}
+
+		if rs == c.newReplicaSet() && status.AvailableReplicas > rs.Status.AvailableReplicas {
+			c.progressed = true
+		}
+
+		rs.Status = status
+
+		_, err := c.clientset.AppsV1().ReplicaSets(c.opts.Namespace).UpdateStatus(ctx, rs, metav1.UpdateOptions{})
+		if err != nil {
+			return err
+		}
+	}
+
+	// Re-read right before writing, so status updates never revert a concurrent spec change (e.g., a rollback)
+	deployments := c.clientset.AppsV1().Deployments(c.opts.Namespace)
+
+	deployment, err := deployments.Get(ctx, c.opts.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	newRS := c.newReplicaSet()
+	desired := *deployment.Spec.Replicas
+	_, unavailable := rollingUpdateLimits(deployment)
+
+	status := deployment.Status.DeepCopy()
+	status.ObservedGeneration = deployment.Generation
+	status.Replicas = total
+	status.UpdatedReplicas = newRS.Status.Replicas
+	status.ReadyReplicas = ready
+	status.AvailableReplicas = available
+	status.UnavailableReplicas = max(0, desired-available)
+
+	if available >= desired-unavailable {
+		setCondition(status, appsv1.DeploymentAvailable, corev1.ConditionTrue,
+			"MinimumReplicasAvailable", "Deployment has minimum availability.")
+	} else {
+		setCondition(status, appsv1.DeploymentAvailable, corev1.ConditionFalse,
+			"MinimumReplicasUnavailable", "Deployment does not have minimum availability.")
+	}
+
+	if c.createFailure != "" {
+		setCondition(status, appsv1.DeploymentReplicaFailure, corev1.ConditionTrue, "FailedCreate", c.createFailure)
+	} else {
+		removeCondition(status, appsv1.DeploymentReplicaFailure)
+	}
+
+	c.updateProgressing(deployment, status, newRS, total)
+
+	if apiequality.Semantic.DeepEqual(&deployment.Status, status) {
+		return nil
+	}
+
+	deployment.Status = *status
+
+	_, err = deployments.UpdateStatus(ctx, deployment, metav1.UpdateOptions{})
+
+	return err
+}
+
+// updateProgressing sets the Progressing condition: complete, progressing, paused or deadline exceeded.
+func (c *Cluster) updateProgressing(
+	deployment *appsv1.Deployment,
+	status *appsv1.DeploymentStatus,
+	newRS *appsv1.ReplicaSet,
+	total int32,
+) {
+	desired := *deployment.Spec.Replicas
+
+	switch {
+	case deployment.Spec.Paused:
+		setCondition(status, appsv1.DeploymentProgressing, corev1.ConditionUnknown,
+			"DeploymentPaused", "Deployment is paused")
+	case newRS.Status.AvailableReplicas == desired && total == desired:
+		setCondition(status, appsv1.DeploymentProgressing, corev1.ConditionTrue,
+			monitor.NewReplicaSetAvailable, fmt.Sprintf("ReplicaSet %q has successfully progressed.", newRS.Name))
+	case c.progressed:
+		c.lastProgress = time.Now()
+		setCondition(status, appsv1.DeploymentProgressing, corev1.ConditionTrue,
+			"ReplicaSetUpdated", fmt.Sprintf("ReplicaSet %q is progressing.", newRS.Name))
+		touchCondition(status, appsv1.DeploymentProgressing)
+	case c.failed || time.Since(c.lastProgress) > c.opts.ProgressDeadline:
+		c.failed = true
+		setCondition(status, appsv1.DeploymentProgressing, corev1.ConditionFalse,
+			monitor.ProgressDeadlineExceeded, fmt.Sprintf("ReplicaSet %q has timed out progressing.", newRS.Name))
+	}
+}
+
+// setCondition sets a deployment condition, keeping its times unless status, reason or message change.
+func setCondition(
+	status *appsv1.DeploymentStatus,
+	condType appsv1.DeploymentConditionType,
+	condStatus corev1.ConditionStatus,
+	reason, message string,
+) {
+	now := metav1.Now()
+	condition := appsv1.DeploymentCondition{
+		Type:               condType,
+		Status:             condStatus,
+		Reason:             reason,
+		Message:            message,
+		LastUpdateTime:     now,
+		LastTransitionTime: now,
+	}
+
+	for i, existing := range status.Conditions {
+		if existing.Type != condType {
+			continue
+		}
+
+		if existing.Status == condStatus {
+			if existing.Reason == reason && existing.Message == message {
+				return
+			}
+
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+
+		status.Conditions[i] = condition
+
+		return
+	}
+
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// removeCondition drops a deployment condition.
+func removeCondition(status *appsv1.DeploymentStatus, condType appsv1.DeploymentConditionType) {
+	status.Conditions = slices.DeleteFunc(status.Conditions, func(c appsv1.DeploymentCondition) bool {
+		return c.Type == condType
+	})
+}
+
+// touchCondition refreshes LastUpdateTime of a condition, as the controller does on every progress.
+func touchCondition(status *appsv1.DeploymentStatus, condType appsv1.DeploymentConditionType) {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			status.Conditions[i].LastUpdateTime = metav1.Now()
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package simulator
+
+// This file contains the simulated scheduler and kubelet: pod creation, container starts and failures.
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	// scheduledAge is the pod age in steps at which it is bound to a node and pulls its image
+	scheduledAge = 1
+	// readyAge is the pod age in steps at which its container starts
+	readyAge = 2
+	// crashExitCode is the exit code of crashing containers
+	crashExitCode = 1
+	// quotaPodCPU is the CPU limit of a simulated pod, the quota fits exactly the desired replicas
+	quotaPodCPU = 1
+)
+
+// ready reports whether the pod passes its readiness probe.
+func (p *simPod) ready() bool {
+	return podReady(p.pod)
+}
+
+// podReady reports whether the pod's Ready condition is true.
+func podReady(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// healthy reports whether pods of a ReplicaSet start healthy: the previous revision
+// always does, the new one depends on the scenario.
+func (c *Cluster) healthy(rs *appsv1.ReplicaSet) bool {
+	return c.opts.Scenario == ScenarioSuccess || replicaSetImage(rs) == oldImage
+}
+
+// exceedsQuota reports whether the namespace quota rejects new pods of a ReplicaSet.
+func (c *Cluster) exceedsQuota(rs *appsv1.ReplicaSet) bool {
+	return c.opts.Scenario == ScenarioQuota && !c.healthy(rs)
+}
+
+// rejectPod reports a pod creation rejected by the resource quota, as the ReplicaSet controller does.
+func (c *Cluster) rejectPod(ctx context.Context, rs *appsv1.ReplicaSet) error {
+	used := c.opts.Replicas * quotaPodCPU
+
+	c.createFailure = fmt.Sprintf("pods %q is forbidden: exceeded quota: compute-resources, "+
+		"requested: limits.cpu=%d, used: limits.cpu=%d, limited: limits.cpu=%d",
+		podName(rs), quotaPodCPU, used, used)
+
+	return c.recordEvent(ctx, "ReplicaSet", rs, "replicaset-controller",
+		corev1.EventTypeWarning, "FailedCreate", "Error creating: "+c.createFailure)
+}
+
+// podName generates a name for a new pod of a ReplicaSet.
+func podName(rs *appsv1.ReplicaSet) string {
+	return rs.Name + "-" + rand.String(5) //nolint:mnd // K8s generateName suffix length
+}
+
+// createPod creates a pending pod for a ReplicaSet.
+func (c *Cluster) createPod(ctx context.Context, rs *appsv1.ReplicaSet) (*simPod, error) {
+	name := podName(rs)
+	pod := &corev1.Pod{
+		ObjectMeta: c.objectMeta(name, rs.Spec.Template.Labels),
+		Spec:       *rs.Spec.Template.Spec.DeepCopy(),
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, LastTransitionTime: metav1.Now()},
+			},
+		},
+	}
+	pod.OwnerReferences = []metav1.OwnerReference{controllerRef("ReplicaSet", rs)}
+
+	pod, err := c.clientset.CoreV1().Pods(c.opts.Namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &simPod{pod: pod}
+	c.pods = append(c.pods, sim)
+
+	if rs == c.newReplicaSet() {
+		c.createFailure = ""
+	}
+
+	return sim, c.recordEvent(ctx, "ReplicaSet", rs, "replicaset-controller",
+		corev1.EventTypeNormal, "SuccessfulCreate", "Created pod: "+name)
+}
+
+// deletePod marks a pod as terminating; it is removed from the cluster on the next kubelet run.
+func (c *Cluster) deletePod(ctx context.Context, rs *appsv1.ReplicaSet, pod *simPod) error {
+	now := metav1.Now()
+	pod.deleting = true
+	pod.pod.DeletionTimestamp = &now
+
+	err := c.updatePod(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	return c.recordEvent(ctx, "ReplicaSet", rs, "replicaset-controller",
+		corev1.EventTypeNormal, "SuccessfulDelete", "Deleted pod: "+pod.pod.Name)
+}
+
+// runKubelet advances every pod one step through its lifecycle.
+func (c *Cluster) runKubelet(ctx context.Context) error {
+	var remaining []*simPod
+
+	for _, pod := range c.pods {
+		if pod.deleting {
+			err := c.clientset.CoreV1().Pods(c.opts.Namespace).Delete(ctx, pod.pod.Name, metav1.DeleteOptions{})
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		remaining = append(remaining, pod)
+		pod.age++
+
+		err := c.advancePod(ctx, pod)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.pods = remaining
+
+	return nil
+}
+
+// advancePod schedules, starts or crashes a pod depending on its age and the scenario.
+func (c *Cluster) advancePod(ctx context.Context, pod *simPod) error {
+	rs := c.podOwner(pod)
+	if rs == nil {
+		return nil
+	}
+
+	image := pod.pod.Spec.Containers[0].Image
+
+	switch {
+	case pod.age == scheduledAge:
+		node := fmt.Sprintf("node-%d", c.uids%simNodes+1)
+		pod.pod.Spec.NodeName = node
+		setPodCondition(pod.pod, corev1.PodScheduled, corev1.ConditionTrue)
+		pod.pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  containerName,
+			Image: image,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"}},
+		}}
+
+		err := c.recordPodEvent(ctx, pod, "default-scheduler", corev1.EventTypeNormal, "Scheduled",
+			fmt.Sprintf("Successfully assigned %s/%s to %s", c.opts.Namespace, pod.pod.Name, node))
+		if err != nil {
+			return err
+		}
+
+		err = c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeNormal, "Pulling",
+			fmt.Sprintf("Pulling image %q", image))
+		if err != nil {
+			return err
+		}
+	case pod.age == readyAge && image == missingImage:
+		err := c.failPull(ctx, pod)
+		if err != nil {
+			return err
+		}
+	case pod.age == readyAge:
+		err := c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeNormal, "Pulled",
+			fmt.Sprintf("Successfully pulled image %q in 1.2s", image))
+		if err != nil {
+			return err
+		}
+
+		startContainer(pod, c.healthy(rs))
+
+		err = c.recordContainerStart(ctx, pod)
+		if err != nil {
+			return err
+		}
+	case pod.age > readyAge && !c.healthy(rs):
+		err := c.failContainer(ctx, pod)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+
+	return c.updatePod(ctx, pod)
+}
+
+// startContainer moves a pod's container to running, ready if the pod is healthy.
+// Unhealthy containers never pass readiness; crash loop ones run only until the next step.
+func startContainer(pod *simPod, ready bool) {
+	now := metav1.Now()
+
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+
+	pod.pod.Status.Phase = corev1.PodRunning
+	pod.pod.Status.StartTime = &now
+	setPodCondition(pod.pod, corev1.PodScheduled, corev1.ConditionTrue)
+	setPodCondition(pod.pod, corev1.PodReady, readyStatus)
+	setPodCondition(pod.pod, corev1.ContainersReady, readyStatus)
+
+	var previous corev1.ContainerStatus
+	if len(pod.pod.Status.ContainerStatuses) > 0 {
+		previous = pod.pod.Status.ContainerStatuses[0]
+	}
+
+	pod.pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:                 containerName,
+		Image:                pod.pod.Spec.Containers[0].Image,
+		Ready:                ready,
+		Started:              &ready,
+		RestartCount:         previous.RestartCount,
+		State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: now}},
+		LastTerminationState: previous.LastTerminationState,
+	}}
+}
+
+// recordContainerStart reports the Created and Started events of a pod's container.
+func (c *Cluster) recordContainerStart(ctx context.Context, pod *simPod) error {
+	err := c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeNormal, "Created",
+		"Created container: "+containerName)
+	if err != nil {
+		return err
+	}
+
+	return c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeNormal, "Started",
+		"Started container "+containerName)
+}
+
+// failContainer advances an unhealthy pod: readiness probes keep failing for the deadline
+// scenario, while crash loop containers alternate between a crash and CrashLoopBackOff.
+func (c *Cluster) failContainer(ctx context.Context, pod *simPod) error {
+	switch c.opts.Scenario {
+	case ScenarioDeadline:
+		return c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeWarning, "Unhealthy",
+			"Readiness probe failed: HTTP probe failed with statuscode: 503")
+	case ScenarioCrashLoop:
+		status := &pod.pod.Status.ContainerStatuses[0]
+
+		if status.State.Running == nil {
+			startContainer(pod, false)
+
+			return c.recordContainerStart(ctx, pod)
+		}
+
+		now := metav1.Now()
+		status.LastTerminationState = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			ExitCode:   crashExitCode,
+			Reason:     "Error",
+			StartedAt:  status.State.Running.StartedAt,
+			FinishedAt: now,
+		}}
+		status.RestartCount++
+		status.State = corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+			Reason: "CrashLoopBackOff",
+			Message: fmt.Sprintf("back-off %s restarting failed container=%s pod=%s_%s(%s)",
+				crashBackOff(status.RestartCount), containerName, pod.pod.Name, c.opts.Namespace, pod.pod.UID),
+		}}
+
+		return c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeWarning, "BackOff",
+			"Back-off restarting failed container "+containerName+" in pod "+pod.pod.Name)
+	case ScenarioImagePull:
+		return c.failPull(ctx, pod)
+	case ScenarioSuccess, ScenarioQuota:
+	}
+
+	return nil
+}
+
+// failPull alternates a container between a failed image pull and the pull back-off.
+func (c *Cluster) failPull(ctx context.Context, pod *simPod) error {
+	status := &pod.pod.Status.ContainerStatuses[0]
+	image := pod.pod.Spec.Containers[0].Image
+
+	if status.State.Waiting != nil && status.State.Waiting.Reason == "ErrImagePull" {
+		status.State.Waiting = &corev1.ContainerStateWaiting{
+			Reason:  "ImagePullBackOff",
+			Message: fmt.Sprintf("Back-off pulling image %q", image),
+		}
+
+		err := c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeNormal, "BackOff",
+			fmt.Sprintf("Back-off pulling image %q", image))
+		if err != nil {
+			return err
+		}
+
+		return c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeWarning, "Failed", "Error: ImagePullBackOff")
+	}
+
+	message := fmt.Sprintf("Failed to pull image %q: rpc error: code = NotFound desc = "+
+		"failed to pull and unpack image %q: not found", image, image)
+	status.State.Waiting = &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: message}
+
+	err := c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeWarning, "Failed", message)
+	if err != nil {
+		return err
+	}
+
+	return c.recordPodEvent(ctx, pod, "kubelet", corev1.EventTypeWarning, "Failed", "Error: ErrImagePull")
+}
+
+// crashBackOff returns the kubelet's restart back-off after the given number of restarts.
+func crashBackOff(restarts int32) time.Duration {
+	const maxBackOff = 5 * time.Minute
+
+	backOff := 10 * time.Second //nolint:mnd // kubelet initial back-off
+	for range restarts - 1 {
+		backOff = min(2*backOff, maxBackOff)
+	}
+
+	return backOff
+}
+
+// podOwner returns the ReplicaSet controlling a pod.
+func (c *Cluster) podOwner(pod *simPod) *appsv1.ReplicaSet {
+	index := slices.IndexFunc(c.replicaSets, func(rs *appsv1.ReplicaSet) bool {
+		return metav1.IsControlledBy(pod.pod, rs)
+	})
+	if index < 0 {
+		return nil
+	}
+
+	return c.replicaSets[index]
+}
+
+// updatePod writes a pod's simulated state to the clientset.
+func (c *Cluster) updatePod(ctx context.Context, pod *simPod) error {
+	updated, err := c.clientset.CoreV1().Pods(c.opts.Namespace).Update(ctx, pod.pod, metav1.UpdateOptions{})
+	if err != nil {
+		return err
+	}
+
+	pod.pod = updated
+
+	return nil
+}
+
+// setPodCondition sets a pod condition, keeping its transition time if the status is unchanged.
+func setPodCondition(pod *corev1.Pod, condType corev1.PodConditionType, status corev1.ConditionStatus) {
+	for i, existing := range pod.Status.Conditions {
+		if existing.Type == condType {
+			if existing.Status != status {
+				pod.Status.Conditions[i] = corev1.PodCondition{
+					Type: condType, Status: status, LastTransitionTime: metav1.Now(),
+				}
+			}
+
+			return
+		}
+	}
+
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{
+		Type: condType, Status: status, LastTransitionTime: metav1.Now(),
+	})
+}
+
+// recordPodEvent reports an event on a pod.
+func (c *Cluster) recordPodEvent(ctx context.Context, pod *simPod, component, eventType, reason, message string) error {
+	return c.recordEvent(ctx, "Pod", pod.pod, component, eventType, reason, message)
+}
+
+// recordEvent reports an event on an object. Repeated events bump the count of the
+// existing one, like the event recorder's aggregation.
+func (c *Cluster) recordEvent(
+	ctx context.Context,
+	kind string,
+	obj metav1.Object,
+	component, eventType, reason, message string,
+) error {
+	events := c.clientset.CoreV1().Events(c.opts.Namespace)
+	now := metav1.Now()
+	key := string(obj.GetUID()) + "/" + reason + "/" + message
+
+	if event, ok := c.events[key]; ok {
+		event.Count++
+		event.LastTimestamp = now
+
+		_, err := events.Update(ctx, event, metav1.UpdateOptions{})
+
+		return err
+	}
+
+	c.uids++
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", obj.GetName(), c.uids),
+			Namespace: c.opts.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:      kind,
+			Namespace: c.opts.Namespace,
+			Name:      obj.GetName(),
+			UID:       obj.GetUID(),
+		},
+		Reason:         reason,
+		Message:        message,
+		Source:         corev1.EventSource{Component: component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+		Type:           eventType,
+	}
+
+	created, err := events.Create(ctx, event, metav1.CreateOptions{})
+	if err != nil {
+		return err
+	}
+
+	c.events[key] = created
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package simulator
+
+// This file contains the monitor data source for simulated clusters.
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ivoronin/kubectl-watch-rollout/internal/monitor"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// source reads simulated objects through the regular repository. The fake clientset cannot
+// serve container logs, so logs are synthesized from the scenario instead.
+type source struct {
+	*monitor.DeploymentRepository
+
+	cluster *Cluster
+}
+
+// GetContainerLogs returns synthetic logs of a simulated container.
+func (s *source) GetContainerLogs(
+	ctx context.Context,
+	podName, container string,
+	_ bool,
+	tailLines int64,
+) ([]string, error) {
+	pod, err := s.cluster.clientset.CoreV1().Pods(s.cluster.opts.Namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch logs of container '%s' in pod '%s': %w", container, podName, err)
+	}
+
+	lines := containerLogs(s.cluster.opts.Scenario, pod.Spec.Containers[0].Image != oldImage)
+	if int64(len(lines)) > tailLines {
+		lines = lines[int64(len(lines))-tailLines:]
+	}
+
+	return lines, nil
+}
+
+// containerLogs returns the log lines a container of the given scenario writes.
+// Only the new revision of failing scenarios logs errors; every instance of a container logs the same.
+func containerLogs(scenario Scenario, newRevision bool) []string {
+	lines := []string{
+		`{"level":"info","msg":"starting web server","version":"1.4.1"}`,
+		`{"level":"info","msg":"listening on :8080"}`,
+	}
+
+	if !newRevision {
+		return lines
+	}
+
+	lines = []string{`{"level":"info","msg":"starting web server","version":"1.4.2"}`}
+
+	switch scenario {
+	case ScenarioDeadline:
+		lines = append(lines, `{"level":"info","msg":"listening on :8080"}`)
+		for i := range 3 { //nolint:mnd // a few failed probes
+			lines = append(lines,
+				fmt.Sprintf(`{"level":"warn","msg":"dependency check failed","dependency":"postgres","attempt":%d}`, i+1),
+				`{"level":"error","msg":"GET /healthz returned 503","reason":"database not reachable"}`)
+		}
+	case ScenarioCrashLoop:
+		lines = append(lines,
+			`{"level":"info","msg":"loading configuration","path":"/etc/web/config.yaml"}`,
+			`{"level":"fatal","msg":"invalid configuration","error":"missing required key \"payments.endpoint\""}`)
+	case ScenarioSuccess, ScenarioImagePull, ScenarioQuota:
+		lines = append(lines, `{"level":"info","msg":"listening on :8080"}`)
+	}
+
+	return lines
+}
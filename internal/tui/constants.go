@@ -35,6 +35,15 @@ var (
 				BorderBottom(true).
 				BorderStyle(lipgloss.NormalBorder()).
 				BorderForeground(ColorGray)
+
+	// focusedTitleStyle highlights the title of the section the arrow keys act on.
+	focusedTitleStyle = sectionTitleStyle.Foreground(ColorGreen)
+
+	// helpBoxStyle frames the keybindings help.
+	helpBoxStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(ColorGray).
+			Padding(1, 2)
 )
 
 // Layout constants.
@@ -60,4 +69,14 @@ const (
 	EventsLastColW = 10
 	// EventsColPadding is the events table column padding.
 	EventsColPadding = 2
+
+	// DashboardCursorColW is the dashboard selection marker column width.
+	DashboardCursorColW = 2
+	// DashboardStatusColW is the dashboard status column width.
+	DashboardStatusColW = 20
+	// DashboardCountColW is the dashboard NEW/OLD column width.
+	DashboardCountColW = 10
+
+	// PodDetailsW is the pod details panel width (content + padding), at most half the screen.
+	PodDetailsW = 50
 )
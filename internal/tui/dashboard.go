@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+const dashboardCursor = "›"
+
+var dashboardCursorStyle = lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
+
+// DashboardTable lists every monitored workload with one summary row each.
+type DashboardTable struct {
+	width     int
+	snapshots map[string]*types.RolloutSnapshot
+	keys      []string // Sorted workload keys, defines row order
+	cursor    int
+}
+
+// NewDashboardTable creates a new dashboard table component.
+func NewDashboardTable() *DashboardTable {
+	return &DashboardTable{snapshots: make(map[string]*types.RolloutSnapshot)}
+}
+
+// SetWidth sets the component width.
+func (m *DashboardTable) SetWidth(w int) { m.width = w }
+
+// Update handles messages.
+func (m *DashboardTable) Update(teaMsg tea.Msg) tea.Cmd {
+	if s, ok := teaMsg.(SnapshotMsg); ok {
+		key := workloadKey(s.Snapshot)
+		if _, exists := m.snapshots[key]; !exists {
+			m.keys = append(m.keys, key)
+			slices.Sort(m.keys)
+		}
+
+		m.snapshots[key] = s.Snapshot
+	}
+
+	return nil
+}
+
+// MoveUp moves the cursor to the previous row.
+func (m *DashboardTable) MoveUp() { m.cursor = max(0, m.cursor-1) }
+
+// MoveDown moves the cursor to the next row.
+func (m *DashboardTable) MoveDown() { m.cursor = min(len(m.keys)-1, m.cursor+1) }
+
+// Len returns the number of workloads shown.
+func (m *DashboardTable) Len() int { return len(m.keys) }
+
+// Selected returns the snapshot under the cursor, or nil if the table is empty.
+// Workloads without rollout data yet are returned too, check their Waiting flag.
+func (m *DashboardTable) Selected() *types.RolloutSnapshot {
+	if m.cursor < 0 || m.cursor >= len(m.keys) {
+		return nil
+	}
+
+	return m.snapshots[m.keys[m.cursor]]
+}
+
+// View renders the component.
+func (m *DashboardTable) View() string {
+	title := sectionTitleStyle.Width(m.width).Render("Rollouts")
+
+	if len(m.keys) == 0 {
+		return title + "\n" + TableLabelStyle.Render("No workloads")
+	}
+
+	rows := make([][]string, len(m.keys))
+	nameW := len("WORKLOAD")
+
+	for i, key := range m.keys {
+		s := m.snapshots[key]
+		cursor := ""
+
+		if i == m.cursor {
+			cursor = dashboardCursorStyle.Render(dashboardCursor)
+		}
+
+		rows[i] = []string{
+			cursor,
+			key,
+			renderDeploymentStatus(s.Status),
+			strconv.Itoa(int(s.NewRS.Available)) + "/" + strconv.Itoa(int(s.UpdateTarget())),
+			strconv.Itoa(int(s.OldRS.Available)),
+			deploymentETAValue(s),
+		}
+
+		// Workloads without rollout data explain why in place of the ETA
+		if s.Waiting {
+			rows[i] = []string{cursor, key, TableLabelStyle.Render("Waiting"), "-", "-", s.StatusMessage}
+		}
+
+		nameW = max(nameW, len(key))
+	}
+
+	colWidths := []int{
+		DashboardCursorColW, nameW + RolloutColPadding, DashboardStatusColW, DashboardCountColW, DashboardCountColW, 0,
+	}
+
+	tbl := table.New().
+		Headers("", "WORKLOAD", "STATUS", "NEW", "OLD", "ETA/DURATION").
+		Rows(rows...).
+		BorderTop(false).BorderBottom(false).BorderLeft(false).BorderRight(false).
+		BorderColumn(false).BorderRow(false).BorderHeader(false).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle()
+			if colWidths[col] > 0 {
+				style = style.Width(colWidths[col])
+			}
+
+			if row == table.HeaderRow {
+				return style.Inherit(TableHeaderStyle)
+			}
+
+			return style
+		}).
+		Render()
+
+	return title + "\n" + tbl
+}
+
+// workloadKey identifies a workload as "kind/name", kubectl style.
+func workloadKey(s *types.RolloutSnapshot) string {
+	return strings.ToLower(string(s.Kind)) + "/" + s.WorkloadName
+}
@@ -2,9 +2,14 @@ package tui
 
 import (
 	"fmt"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
@@ -16,14 +21,39 @@ var (
 	eventsNormalStyle  = lipgloss.NewStyle().Foreground(ColorBlue)
 )
 
+// eventsRecentWindows are the "recent only" windows cycled through, 0 shows all clusters.
+var eventsRecentWindows = []time.Duration{0, 5 * time.Minute, 15 * time.Minute, time.Hour}
+
 // EventsTable is the events table component.
+// Clusters can be filtered by a regular expression, by type and by how recently they were seen,
+// and the selected cluster expanded to show its exemplar messages and pods.
 type EventsTable struct {
 	width    int
 	snapshot *types.RolloutSnapshot
+
+	cursor       string // Key of the selected cluster, "" when none is selected
+	cursorIdx    int    // Position of the selected cluster among visible ones
+	expanded     bool   // Selected cluster shows its exemplars
+	cursorTop    int    // First line of the selected cluster in the last View, -1 without selection
+	cursorBottom int    // Last line of the selected cluster, its exemplars included
+
+	filter     textinput.Model
+	filtering  bool           // Filter input has focus
+	pattern    *regexp.Regexp // Last valid filter, nil shows all clusters
+	patternErr error          // Why the filter being typed does not compile
+	hideNormal bool
+	recent     int // Index into eventsRecentWindows
 }
 
 // NewEventsTable creates a new events component.
-func NewEventsTable() *EventsTable { return &EventsTable{} }
+func NewEventsTable() *EventsTable {
+	filter := textinput.New()
+	filter.Prompt = "/"
+	filter.Placeholder = "regex matching source, reason or message"
+	filter.Cursor.SetMode(cursor.CursorStatic)
+
+	return &EventsTable{filter: filter, cursorTop: -1, cursorBottom: -1}
+}
 
 // SetWidth sets the component width.
 func (m *EventsTable) SetWidth(w int) { m.width = w }
@@ -37,6 +67,160 @@ func (m *EventsTable) Update(teaMsg tea.Msg) tea.Cmd {
 	return nil
 }
 
+// clusterKey identifies a cluster across snapshots, as long as its template does not change.
+func clusterKey(c types.EventCluster) string {
+	return c.Source + "\x00" + c.Type + "\x00" + c.Reason + "\x00" + c.Message
+}
+
+// followCursor keeps the selection on the same cluster, or on its neighbour once the cluster is gone.
+func (m *EventsTable) followCursor(visible []types.EventCluster) {
+	if m.cursor == "" {
+		return
+	}
+
+	if len(visible) == 0 {
+		m.ClearSelection()
+
+		return
+	}
+
+	for i, c := range visible {
+		if clusterKey(c) == m.cursor {
+			m.cursorIdx = i
+
+			return
+		}
+	}
+
+	m.cursorIdx = min(m.cursorIdx, len(visible)-1)
+	m.cursor = clusterKey(visible[m.cursorIdx])
+}
+
+// MoveCursor moves the selection by delta clusters, collapsing the expanded one.
+// Moving down without a selection selects the first cluster, moving up from it clears the selection.
+func (m *EventsTable) MoveCursor(delta int) {
+	if m.snapshot == nil {
+		return
+	}
+
+	visible := m.visibleClusters()
+	m.followCursor(visible)
+
+	if len(visible) == 0 {
+		return
+	}
+
+	m.expanded = false
+
+	next := m.cursorIdx + delta
+	if m.cursor == "" {
+		next = 0
+	}
+
+	switch {
+	case m.cursor == "" && delta < 0:
+	case next < 0:
+		m.ClearSelection()
+	case next < len(visible):
+		m.cursorIdx = next
+		m.cursor = clusterKey(visible[next])
+	}
+}
+
+// HasSelection reports whether a cluster is selected.
+func (m *EventsTable) HasSelection() bool { return m.cursor != "" }
+
+// ClearSelection deselects and collapses the selected cluster.
+func (m *EventsTable) ClearSelection() {
+	m.cursor = ""
+	m.cursorIdx = 0
+	m.expanded = false
+}
+
+// ToggleExpanded shows or hides the exemplars of the selected cluster.
+func (m *EventsTable) ToggleExpanded() { m.expanded = m.cursor != "" && !m.expanded }
+
+// Expanded reports whether the selected cluster shows its exemplars.
+func (m *EventsTable) Expanded() bool { return m.expanded }
+
+// CursorLines returns the first and last line of the selected cluster in the last View, -1 without selection.
+func (m *EventsTable) CursorLines() (int, int) { return m.cursorTop, m.cursorBottom }
+
+// Filtering reports whether the filter input has focus and takes all keys.
+func (m *EventsTable) Filtering() bool { return m.filtering }
+
+// StartFilter focuses the filter input, editing the current filter.
+func (m *EventsTable) StartFilter() {
+	m.filtering = true
+	m.filter.Focus()
+	m.filter.CursorEnd()
+}
+
+// UpdateFilter edits the filter, applying it as it is typed. Enter keeps a valid filter, Esc clears it.
+func (m *EventsTable) UpdateFilter(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case msg.Type == tea.KeyEnter && m.patternErr == nil:
+		m.filtering = false
+		m.filter.Blur()
+
+		return nil
+	case msg.Type == tea.KeyEsc:
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.Reset()
+		m.pattern, m.patternErr = nil, nil
+
+		return nil
+	}
+
+	var cmd tea.Cmd
+
+	m.filter, cmd = m.filter.Update(msg)
+
+	pattern, err := regexp.Compile("(?i)" + m.filter.Value())
+	m.patternErr = err
+
+	switch {
+	case err != nil:
+	case m.filter.Value() == "":
+		m.pattern = nil
+	default:
+		m.pattern = pattern
+	}
+
+	return cmd
+}
+
+// ToggleHideNormal hides or shows Normal events.
+func (m *EventsTable) ToggleHideNormal() { m.hideNormal = !m.hideNormal }
+
+// CycleRecent switches to the next "recent only" window.
+func (m *EventsTable) CycleRecent() { m.recent = (m.recent + 1) % len(eventsRecentWindows) }
+
+// filtered reports whether any filter hides clusters.
+func (m *EventsTable) filtered() bool {
+	return m.pattern != nil || m.hideNormal || m.recent > 0
+}
+
+// visibleClusters returns the clusters passing the filters.
+func (m *EventsTable) visibleClusters() []types.EventCluster {
+	window := eventsRecentWindows[m.recent]
+
+	var visible []types.EventCluster
+
+	for _, c := range m.snapshot.Events.Clusters {
+		switch {
+		case m.hideNormal && c.Type != "Warning":
+		case window > 0 && time.Since(c.LastSeen) > window:
+		case m.pattern != nil && !m.pattern.MatchString(c.Source+" "+c.Reason+" "+c.Message):
+		default:
+			visible = append(visible, c)
+		}
+	}
+
+	return visible
+}
+
 // View renders the component.
 func (m *EventsTable) View() string {
 	if m.snapshot == nil {
@@ -45,40 +229,52 @@ func (m *EventsTable) View() string {
 
 	title := m.buildEventsTitle()
 
-	events := m.snapshot.Events.Clusters
+	if m.filtering || m.patternErr != nil {
+		title += "\n" + m.buildFilterLine()
+	}
+
+	events := m.visibleClusters()
+	m.followCursor(events)
+	m.cursorTop, m.cursorBottom = -1, -1
+
 	if len(events) == 0 {
+		if m.filtered() && len(m.snapshot.Events.Clusters) > 0 {
+			return title + "\n" + TableLabelStyle.Render("No matching events")
+		}
+
 		return title + "\n" + TableLabelStyle.Render("No events")
 	}
 
 	rows := formatEventRows(events)
 
 	// Calculate column widths
-	reasonW, similarW := EventsMinColW, EventsMinColW
+	sourceW, reasonW, similarW := len("SOURCE"), EventsMinColW, EventsMinColW
 	for _, r := range rows {
+		sourceW = max(sourceW, len(r.source))
 		reasonW = max(reasonW, len(r.reason))
 		similarW = max(similarW, len(r.similar))
 	}
 
-	msgW := max(EventsMinColW, m.width-RolloutLabelColW-reasonW-similarW-EventsLastColW-4*EventsColPadding)
+	msgW := max(EventsMinColW, m.width-RolloutLabelColW-sourceW-reasonW-similarW-EventsLastColW-5*EventsColPadding)
 	colWidths := []int{
-		RolloutLabelColW + EventsColPadding, reasonW + EventsColPadding,
+		RolloutLabelColW + EventsColPadding, sourceW + EventsColPadding, reasonW + EventsColPadding,
 		msgW + EventsColPadding, similarW + EventsColPadding, EventsLastColW,
 	}
 
 	// Build table rows
 	tableRows := make([][]string, len(rows))
 	for i, r := range rows {
-		tableRows[i] = []string{r.eventType, r.reason, truncateStr(r.message, msgW), r.similar, r.last}
+		tableRows[i] = []string{r.eventType, r.source, r.reason, truncateStr(r.message, msgW), r.similar, r.last}
 	}
 
 	tbl := table.New().
-		Headers("TYPE", "REASON", "MESSAGE", "EXEMPLARS", "LAST").
+		Headers("TYPE", "SOURCE", "REASON", "MESSAGE", "EXEMPLARS", "LAST").
 		Rows(tableRows...).
 		BorderTop(false).BorderBottom(false).BorderLeft(false).BorderRight(false).
 		BorderColumn(false).BorderRow(false).BorderHeader(false).
 		StyleFunc(func(row, col int) lipgloss.Style {
 			style := lipgloss.NewStyle().Width(colWidths[col])
-			if col >= 3 {
+			if col >= 4 {
 				style = style.Align(lipgloss.Right)
 			}
 
@@ -86,14 +282,73 @@ func (m *EventsTable) View() string {
 				return style.Inherit(TableHeaderStyle)
 			}
 
-			return style
+			return style.Reverse(m.cursor != "" && row == m.cursorIdx)
 		}).
 		Render()
 
-	return title + "\n" + tbl
+	if m.cursor == "" {
+		return title + "\n" + tbl
+	}
+
+	// Rows are single lines below the header, the exemplars go right below the selected one
+	lines := strings.Split(tbl, "\n")
+	row := m.cursorIdx + 1
+
+	var exemplars []string
+	if m.expanded {
+		exemplars = m.buildExemplars(events[m.cursorIdx])
+	}
+
+	m.cursorTop = lipgloss.Height(title) + row
+	m.cursorBottom = m.cursorTop + len(exemplars)
+
+	lines = slices.Insert(lines, row+1, exemplars...)
+
+	return title + "\n" + strings.Join(lines, "\n")
 }
 
-// buildEventsTitle creates the section title with event stats.
+// buildExemplars renders the details of an expanded cluster: when it was seen,
+// the pods involved, and its distinct messages with their counts.
+func (m *EventsTable) buildExemplars(c types.EventCluster) []string {
+	const indent = "  "
+
+	seen := TableLabelStyle.Render("Last seen ") + types.FormatDuration(time.Since(c.LastSeen)) + " ago"
+	if !c.FirstSeen.IsZero() {
+		seen = TableLabelStyle.Render("First seen ") + types.FormatDuration(time.Since(c.FirstSeen)) + " ago, " +
+			TableLabelStyle.Render("last seen ") + types.FormatDuration(time.Since(c.LastSeen)) + " ago"
+	}
+
+	lines := []string{indent + seen}
+
+	if len(c.Pods) > 0 {
+		pods := TableLabelStyle.Render(fmt.Sprintf("Pods (%d) ", len(c.Pods))) + strings.Join(c.Pods, ", ")
+		wrapped := lipgloss.NewStyle().Width(max(1, m.width-len(indent))).Render(pods)
+
+		for _, line := range strings.Split(wrapped, "\n") {
+			lines = append(lines, indent+line)
+		}
+	}
+
+	// Align messages past the widest count and age
+	counts, ages := make([]string, len(c.Exemplars)), make([]string, len(c.Exemplars))
+	countW, ageW := 0, 0
+
+	for i, e := range c.Exemplars {
+		counts[i] = "×" + strconv.Itoa(e.Count)
+		ages[i] = types.FormatDuration(time.Since(e.LastSeen)) + " ago"
+		countW, ageW = max(countW, len(counts[i])), max(ageW, len(ages[i]))
+	}
+
+	for i, e := range c.Exemplars {
+		stats := fmt.Sprintf("%-*s  %*s  ", countW, counts[i], ageW, ages[i])
+		lines = append(lines, indent+TableLabelStyle.Render(stats)+
+			truncateStr(e.Message, max(0, m.width-len(indent)-lipgloss.Width(stats))))
+	}
+
+	return lines
+}
+
+// buildEventsTitle creates the section title with the active filters and event stats.
 func (m *EventsTable) buildEventsTitle() string {
 	totalEvents := 0
 	for _, c := range m.snapshot.Events.Clusters {
@@ -101,14 +356,48 @@ func (m *EventsTable) buildEventsTitle() string {
 	}
 
 	stats := fmt.Sprintf("TOTAL %d  IGNORED %d", totalEvents, m.snapshot.Events.IgnoredCount)
-	titleContent := "Events" + lipgloss.PlaceHorizontal(m.width-lipgloss.Width("Events"), lipgloss.Right, stats)
+	if m.filtered() {
+		stats = fmt.Sprintf("SHOWN %d/%d  %s", len(m.visibleClusters()), len(m.snapshot.Events.Clusters), stats)
+	}
+
+	left := "Events"
+
+	var filters []string
+
+	if m.pattern != nil && !m.filtering {
+		filters = append(filters, "/"+m.filter.Value()+"/")
+	}
+
+	if m.hideNormal {
+		filters = append(filters, "warnings only")
+	}
+
+	if window := eventsRecentWindows[m.recent]; window > 0 {
+		filters = append(filters, "last "+types.FormatDuration(window))
+	}
+
+	if len(filters) > 0 {
+		left += " (" + strings.Join(filters, ", ") + ")"
+	}
+
+	titleContent := left + lipgloss.PlaceHorizontal(max(0, m.width-lipgloss.Width(left)), lipgloss.Right, stats)
 
 	return sectionTitleStyle.Width(m.width).Render(titleContent)
 }
 
+// buildFilterLine renders the filter input, flagging an invalid regular expression.
+func (m *EventsTable) buildFilterLine() string {
+	line := m.filter.View()
+	if m.patternErr != nil {
+		line += "  " + eventsWarningStyle.Render("invalid regex")
+	}
+
+	return line
+}
+
 // eventRow holds formatted data for a single event row.
 type eventRow struct {
-	eventType, reason, message, similar, last string
+	eventType, source, reason, message, similar, last string
 }
 
 // formatEventRows converts event clusters to formatted row data.
@@ -118,6 +407,7 @@ func formatEventRows(events []types.EventCluster) []eventRow {
 	for i, e := range events {
 		rows[i] = eventRow{
 			eventType: formatEventType(e.Type),
+			source:    e.Source,
 			reason:    e.Reason,
 			message:   e.Message,
 			similar:   formatExemplars(e.ExemplarCount),
@@ -4,25 +4,146 @@ import "github.com/charmbracelet/bubbles/key"
 
 // KeyMap defines keybindings for the TUI
 type KeyMap struct {
-	Quit key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	Left       key.Binding
+	Right      key.Binding
+	Navigate   key.Binding // Help entry summarizing the arrows while they select pods, not matched
+	PageUp     key.Binding
+	PageDown   key.Binding
+	Focus      key.Binding
+	Filter     key.Binding
+	HideNormal key.Binding
+	Recent     key.Binding
+	Select     key.Binding
+	Back       key.Binding
+	Help       key.Binding
+	Quit       key.Binding
 }
 
-// DefaultKeyMap returns the default keybindings
+// DefaultKeyMap returns the default keybindings.
+// Dashboard navigation bindings start disabled and are enabled by the model per view.
 func DefaultKeyMap() KeyMap {
-	return KeyMap{
+	keys := KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		Left: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "left"),
+		),
+		Right: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "right"),
+		),
+		Navigate: key.NewBinding(
+			key.WithKeys("left", "down", "up", "right"),
+			key.WithHelp("←↓↑→", "select pod"),
+		),
+		PageUp: key.NewBinding(
+			key.WithKeys("pgup"),
+			key.WithHelp("pgup", "page up"),
+		),
+		PageDown: key.NewBinding(
+			key.WithKeys("pgdown"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		Focus: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "events/pods"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		HideNormal: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "hide normal"),
+		),
+		Recent: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "recent only"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "details"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
 		),
 	}
+	keys.setDashboardMode(false, false)
+
+	return keys
+}
+
+// setDashboardMode enables the navigation bindings relevant to the current view.
+// On the dashboard table rows can be selected; a rollout view scrolls, filters and expands events,
+// selects pods in the grid, and Back returns to the table. Rollout views start focused on events.
+func (k *KeyMap) setDashboardMode(dashboard, onTable bool) {
+	table := dashboard && onTable
+
+	k.Up.SetEnabled(true)
+	k.Down.SetEnabled(true)
+	k.Select.SetEnabled(true)
+	k.Back.SetEnabled(dashboard && !onTable)
+
+	if table {
+		k.Select.SetHelp("enter", "details")
+	} else {
+		k.Select.SetHelp("enter", "expand")
+	}
+
+	for _, b := range []*key.Binding{&k.PageUp, &k.PageDown, &k.Focus, &k.Filter, &k.HideNormal, &k.Recent, &k.Help} {
+		b.SetEnabled(!table)
+	}
+
+	k.setPodsFocus(false)
+}
+
+// setPodsFocus enables the arrows selecting pods while the pods grid has focus.
+func (k *KeyMap) setPodsFocus(pods bool) {
+	k.Left.SetEnabled(pods)
+	k.Right.SetEnabled(pods)
+	k.Navigate.SetEnabled(pods)
+}
+
+// setSelection enables Back while a pod is selected or an event cluster expanded, it clears them first.
+func (k *KeyMap) setSelection(dashboard, selected bool) {
+	k.Back.SetEnabled(dashboard || selected)
 }
 
 // ShortHelp implements help.KeyMap
 func (k KeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Quit}
+	switch {
+	case !k.Focus.Enabled(): // Dashboard table or picker
+		return []key.Binding{k.Up, k.Down, k.Select, k.Back, k.Quit}
+	case k.Navigate.Enabled():
+		return []key.Binding{k.Navigate, k.Focus, k.Back, k.Help, k.Quit}
+	}
+
+	return []key.Binding{k.Up, k.Down, k.Select, k.Focus, k.Filter, k.Back, k.Help, k.Quit}
 }
 
 // FullHelp implements help.KeyMap
 func (k KeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Quit}}
+	return [][]key.Binding{
+		{k.Up, k.Down, k.Left, k.Right, k.PageUp, k.PageDown},
+		{k.Focus, k.Filter, k.HideNormal, k.Recent},
+		{k.Select, k.Back, k.Help, k.Quit},
+	}
 }
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+var logsPreviousStyle = lipgloss.NewStyle().Foreground(ColorGray)
+
+// LogsTable is the clustered container logs component.
+// It renders nothing until logs of failing containers have been sampled.
+type LogsTable struct {
+	width    int
+	snapshot *types.RolloutSnapshot
+}
+
+// NewLogsTable creates a new logs component.
+func NewLogsTable() *LogsTable { return &LogsTable{} }
+
+// SetWidth sets the component width.
+func (m *LogsTable) SetWidth(w int) { m.width = w }
+
+// Update handles messages.
+func (m *LogsTable) Update(teaMsg tea.Msg) tea.Cmd {
+	if t, ok := teaMsg.(SnapshotMsg); ok {
+		m.snapshot = t.Snapshot
+	}
+
+	return nil
+}
+
+// View renders the component.
+func (m *LogsTable) View() string {
+	if m.snapshot == nil || len(m.snapshot.Logs.Clusters) == 0 {
+		return ""
+	}
+
+	clusters := m.snapshot.Logs.Clusters
+
+	containerW := EventsMinColW
+	for _, c := range clusters {
+		containerW = max(containerW, lipgloss.Width(formatLogContainer(c)))
+	}
+
+	templateW := max(EventsMinColW, m.width-containerW-EventsLastColW-2*EventsColPadding)
+	colWidths := []int{containerW + EventsColPadding, EventsLastColW + EventsColPadding, templateW}
+
+	rows := make([][]string, len(clusters))
+	for i, c := range clusters {
+		rows[i] = []string{formatLogContainer(c), strconv.Itoa(c.Count), truncateStr(c.Template, templateW)}
+	}
+
+	tbl := table.New().
+		Headers("CONTAINER", "LINES", "TEMPLATE").
+		Rows(rows...).
+		BorderTop(false).BorderBottom(false).BorderLeft(false).BorderRight(false).
+		BorderColumn(false).BorderRow(false).BorderHeader(false).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Width(colWidths[col])
+			if row == table.HeaderRow {
+				return style.Inherit(TableHeaderStyle)
+			}
+
+			return style
+		}).
+		Render()
+
+	return m.buildLogsTitle() + "\n" + tbl
+}
+
+// buildLogsTitle creates the section title with sampling stats.
+func (m *LogsTable) buildLogsTitle() string {
+	logs := m.snapshot.Logs
+	stats := fmt.Sprintf("PODS %d  LINES %d", len(logs.Pods), logs.Lines)
+	titleContent := "Logs" + lipgloss.PlaceHorizontal(m.width-lipgloss.Width("Logs"), lipgloss.Right, stats)
+
+	return sectionTitleStyle.Width(m.width).Render(titleContent)
+}
+
+// formatLogContainer names the container, marking logs of its previous instance.
+func formatLogContainer(c types.LogCluster) string {
+	if c.Previous {
+		return c.Container + logsPreviousStyle.Render(" (previous)")
+	}
+
+	return c.Container
+}
@@ -1,6 +1,11 @@
 package tui
 
 import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	bubbleprogress "github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -15,40 +20,78 @@ type Model struct {
 	hasData       bool
 	quitting      bool
 
+	// waiting explains why a single workload has no rollout data yet, shown while loading
+	waiting string
+
+	// selected is the drilled-in workload key in dashboard mode ("" while on the table)
+	selected string
+	// focusPods routes the arrows to the pods grid instead of scrolling the events panel
+	focusPods bool
+	// showHelp replaces the rollout view with the full list of keybindings
+	showHelp bool
+
 	spinner        spinner.Model
-	keys           KeyMap
+	keys           *KeyMap
+	dashboard      *DashboardTable // nil unless several workloads are monitored
 	rolloutInfo    *RolloutInfo
 	progressBar    *ProgressBar
 	podStats       *PodStats
 	podsGrid       *PodsGrid
+	podDetails     *PodDetails
+	problemsTable  *ProblemsTable
+	logsTable      *LogsTable
 	eventsTable    *EventsTable
-	eventsViewport viewport.Model
+	eventsViewport *viewport.Model // Pointer: View sizes it, Update scrolls it
 	statusbar      *Statusbar
+	help           help.Model
 }
 
-// NewModel creates a new TUI model
-func NewModel() Model {
+// NewModel creates a new TUI model.
+// In dashboard mode the model starts on the workload table.
+func NewModel(dashboard bool) Model {
 	keys := DefaultKeyMap()
+	keys.setDashboardMode(dashboard, true)
+
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(ColorGreen)
 
+	var table *DashboardTable
+	if dashboard {
+		table = NewDashboardTable()
+	}
+
+	eventsViewport := viewport.New(0, 0)
+
+	fullHelp := help.New()
+	fullHelp.ShowAll = true
+
 	return Model{
 		spinner:        s,
-		keys:           keys,
+		keys:           &keys,
+		dashboard:      table,
 		rolloutInfo:    NewRolloutInfo(),
 		progressBar:    NewProgressBar(),
 		podStats:       NewPodStats(),
 		podsGrid:       NewPodsGrid(),
+		podDetails:     NewPodDetails(),
+		problemsTable:  NewProblemsTable(),
+		logsTable:      NewLogsTable(),
 		eventsTable:    NewEventsTable(),
-		eventsViewport: viewport.New(0, 0),
-		statusbar:      NewStatusbar(keys),
+		eventsViewport: &eventsViewport,
+		statusbar:      NewStatusbar(&keys),
+		help:           fullHelp,
 	}
 }
 
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(tickCmd(), m.spinner.Tick)
+	cmds := []tea.Cmd{tickCmd(), m.spinner.Tick}
+	if m.dashboard != nil {
+		cmds = append(cmds, tea.SetWindowTitle("kubectl-watch-rollout"))
+	}
+
+	return tea.Batch(cmds...)
 }
 
 // Update implements tea.Model
@@ -60,16 +103,58 @@ func (m Model) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width, m.height = t.Width, t.Height
 
 	case tea.KeyMsg:
+		// The filter input takes every key, "q" included
+		if m.eventsTable.Filtering() && t.Type != tea.KeyCtrlC {
+			cmd := m.eventsTable.UpdateFilter(t)
+			m.scrollToEvents()
+
+			return m, cmd
+		}
+
 		if key.Matches(t, m.keys.Quit) {
 			m.quitting = true
 
 			return m, tea.Quit
 		}
 
+		var cmd tea.Cmd
+
+		if m.dashboard != nil && m.selected == "" {
+			m, cmd = m.handleDashboardKey(t)
+		} else {
+			m = m.handleDetailKey(t)
+		}
+
+		cmds = append(cmds, cmd)
+
+	case tea.MouseMsg:
+		if m.dashboard == nil || m.selected != "" {
+			*m.eventsViewport, _ = m.eventsViewport.Update(t)
+		}
+
 	case SnapshotMsg:
+		// A single workload keeps loading until it has rollout data, and keeps its last data after
+		if m.dashboard == nil && t.Snapshot.Waiting {
+			m.waiting = t.Snapshot.StatusMessage
+
+			break
+		}
+
 		firstSnapshot := !m.hasData
 		m.hasData = true
+
+		if m.dashboard != nil {
+			m.dashboard.Update(t)
+
+			// Detail components only follow the drilled-in workload and keep
+			// its last rollout data while it is waiting
+			if m.selected != workloadKey(t.Snapshot) || t.Snapshot.Waiting {
+				break
+			}
+		}
+
 		cmds = append(cmds, m.updateComponents(t, firstSnapshot)...)
+		m.keys.setSelection(m.dashboard != nil, m.hasSelection())
 
 	case spinner.TickMsg:
 		if !m.hasData {
@@ -112,7 +197,15 @@ func (m Model) View() string {
 
 	if !m.hasData {
 		return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center,
-			m.spinner.View()+"Loading...")
+			m.spinner.View()+cmp.Or(m.waiting, "Loading..."))
+	}
+
+	if m.dashboard != nil && m.selected == "" {
+		return m.viewDashboard()
+	}
+
+	if m.showHelp {
+		return m.viewHelp()
 	}
 
 	// Layout:
@@ -121,7 +214,8 @@ func (m Model) View() string {
 	// ┝━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┥ ProgressH (statusbar border)
 	// │  rolloutInfo  │  podStats   │ topH (content + padding)
 	// ├───────────────┴─────────────┤
-	// │        eventsTable          │ eventsH (flex)
+	// │ problems + logs + events    │ eventsH (flex, problems and logs only when present;
+	// │               │ podDetails  │          podDetails only while a pod is selected)
 	// ├─────────────────────────────┤
 	// │        podsGrid             │ podsGridH (content-driven)
 	// └─────────────────────────────┘
@@ -148,15 +242,27 @@ func (m Model) View() string {
 	// Calculate flex height for events
 	eventsH := m.height - StatusbarH - topH - podsGridH - ProgressH
 
+	// The selected pod's details take the right side of the events row
+	pod := m.podsGrid.Selected()
+	eventsW, detailsW := m.width, 0
+
+	if pod != nil {
+		detailsW = min(PodDetailsW, m.width/2)
+		eventsW -= detailsW
+		contentWidth = eventsW - panelHFrame
+	}
+
 	// Size remaining flex components
-	m.progressBar.SetWidth(contentWidth) // with 1 char L/R padding
+	m.progressBar.SetWidth(m.width - panelHFrame) // with 1 char L/R padding
+	m.problemsTable.SetWidth(contentWidth)
+	m.logsTable.SetWidth(contentWidth)
 	m.eventsTable.SetWidth(contentWidth)
-	m.statusbar.SetWidth(contentWidth)
+	m.statusbar.SetWidth(m.width - panelHFrame)
 
 	// Size and populate events viewport
 	m.eventsViewport.Width = contentWidth
 	m.eventsViewport.Height = max(0, eventsH-panelVFrame)
-	m.eventsViewport.SetContent(m.eventsTable.View())
+	m.eventsViewport.SetContent(m.eventsContent())
 
 	// Compose layout
 	statusRow := rowPaddingStyle.Render(m.statusbar.View())
@@ -164,7 +270,14 @@ func (m Model) View() string {
 		topPanelPaddingStyle.Width(rolloutW).Height(topH).Render(rollout),
 		topPanelPaddingStyle.Width(statsW).Height(topH).Render(stats),
 	)
-	eventsRow := panelPaddingStyle.Width(m.width).Height(eventsH).Render(m.eventsViewport.View())
+	eventsRow := panelPaddingStyle.Width(eventsW).Height(eventsH).Render(m.eventsViewport.View())
+
+	if pod != nil {
+		m.podDetails.SetWidth(detailsW - panelHFrame)
+		m.podDetails.SetPod(pod)
+		eventsRow = lipgloss.JoinHorizontal(lipgloss.Top, eventsRow,
+			panelPaddingStyle.Width(detailsW).Height(eventsH).MaxHeight(eventsH).Render(m.podDetails.View()))
+	}
 	progressRow := rowPaddingStyle.Render(m.progressBar.View()) // 1 char L/R padding
 
 	return lipgloss.JoinVertical(lipgloss.Left,
@@ -176,6 +289,184 @@ func (m Model) View() string {
 	)
 }
 
+// eventsContent stacks the problems and logs sections, when there are failing containers, above the events table.
+func (m Model) eventsContent() string {
+	var sections []string
+
+	for _, section := range []string{m.problemsTable.View(), m.logsTable.View(), m.eventsTable.View()} {
+		if section != "" {
+			sections = append(sections, section)
+		}
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// hasSelection reports whether Back has a pod selection or expanded event cluster to clear.
+func (m Model) hasSelection() bool {
+	return m.podsGrid.Selected() != nil || m.eventsTable.Expanded()
+}
+
+// eventsOffset returns the line of the events panel the events table starts at, below the problems and logs sections.
+func (m Model) eventsOffset() int {
+	offset := 0
+
+	for _, section := range []string{m.problemsTable.View(), m.logsTable.View()} {
+		if section != "" {
+			offset += lipgloss.Height(section) + 1 // Blank line between sections
+		}
+	}
+
+	return offset
+}
+
+// scrollToEvents scrolls the events panel to the events table.
+func (m Model) scrollToEvents() {
+	m.eventsViewport.SetContent(m.eventsContent())
+	m.eventsViewport.SetYOffset(m.eventsOffset())
+}
+
+// followEventsCursor scrolls the events panel just enough to show the selected cluster and its exemplars.
+func (m Model) followEventsCursor() {
+	m.eventsViewport.SetContent(m.eventsContent())
+
+	top, bottom := m.eventsTable.CursorLines()
+	if top < 0 {
+		return
+	}
+
+	offset := m.eventsOffset()
+	top, bottom = top+offset, bottom+offset
+
+	if bottom >= m.eventsViewport.YOffset+m.eventsViewport.Height {
+		m.eventsViewport.SetYOffset(bottom - m.eventsViewport.Height + 1)
+	}
+
+	if top < m.eventsViewport.YOffset {
+		m.eventsViewport.SetYOffset(top)
+	}
+}
+
+// viewHelp renders the full list of keybindings below the statusbar.
+func (m Model) viewHelp() string {
+	m.statusbar.SetWidth(m.width - panelPaddingStyle.GetHorizontalFrameSize())
+
+	statusRow := rowPaddingStyle.Render(m.statusbar.View())
+	box := helpBoxStyle.Render(sectionTitleStyle.Render("Keys") + "\n" + m.help.FullHelpView(m.keys.FullHelp()))
+
+	return lipgloss.JoinVertical(lipgloss.Left, statusRow,
+		lipgloss.Place(m.width, m.height-StatusbarH, lipgloss.Center, lipgloss.Center, box))
+}
+
+// viewDashboard renders the multi-workload table below the statusbar.
+func (m Model) viewDashboard() string {
+	contentWidth := m.width - panelPaddingStyle.GetHorizontalFrameSize()
+
+	m.statusbar.SetWidth(contentWidth)
+	m.statusbar.SetSummary(fmt.Sprintf("Watching %d rollouts", m.dashboard.Len()))
+	m.dashboard.SetWidth(contentWidth)
+
+	statusRow := rowPaddingStyle.Render(m.statusbar.View())
+	tableH := m.height - StatusbarH
+	tableRow := panelPaddingStyle.Width(m.width).Height(tableH).MaxHeight(tableH).Render(m.dashboard.View())
+
+	return lipgloss.JoinVertical(lipgloss.Left, statusRow, tableRow)
+}
+
+// handleDashboardKey moves the table cursor or drills into a workload.
+func (m Model) handleDashboardKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Up):
+		m.dashboard.MoveUp()
+	case key.Matches(msg, m.keys.Down):
+		m.dashboard.MoveDown()
+	case key.Matches(msg, m.keys.Select):
+		snapshot := m.dashboard.Selected()
+		if snapshot == nil || snapshot.Waiting {
+			return m, nil
+		}
+
+		m.selected = workloadKey(snapshot)
+		m.keys.setDashboardMode(true, false)
+		m.statusbar.SetSummary("")
+		m.podsGrid.ClearSelection()
+		m.eventsTable.ClearSelection()
+		m = m.setPodsFocus(false)
+		m.eventsViewport.GotoTop()
+
+		return m, tea.Batch(m.updateComponents(SnapshotMsg{Snapshot: snapshot}, false)...)
+	}
+
+	return m, nil
+}
+
+// handleDetailKey selects, expands and filters events or selects pods in the grid, depending on focus.
+// Back collapses the expanded event cluster, deselects the pod, then returns to the dashboard table.
+func (m Model) handleDetailKey(msg tea.KeyMsg) Model {
+	if m.showHelp {
+		m.showHelp = false // Any key closes the help
+
+		return m
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Help):
+		m.showHelp = true
+	case key.Matches(msg, m.keys.Focus):
+		m = m.setPodsFocus(!m.focusPods)
+	case key.Matches(msg, m.keys.Filter):
+		m.eventsTable.StartFilter()
+		m.scrollToEvents()
+	case key.Matches(msg, m.keys.HideNormal):
+		m.eventsTable.ToggleHideNormal()
+		m.scrollToEvents()
+	case key.Matches(msg, m.keys.Recent):
+		m.eventsTable.CycleRecent()
+		m.scrollToEvents()
+	case key.Matches(msg, m.keys.PageUp):
+		m.eventsViewport.PageUp()
+	case key.Matches(msg, m.keys.PageDown):
+		m.eventsViewport.PageDown()
+	case key.Matches(msg, m.keys.Up) && !m.focusPods:
+		if m.eventsTable.HasSelection() {
+			m.eventsTable.MoveCursor(-1)
+			m.followEventsCursor()
+		} else {
+			m.eventsViewport.ScrollUp(1)
+		}
+	case key.Matches(msg, m.keys.Down) && !m.focusPods:
+		m.eventsTable.MoveCursor(1)
+		m.followEventsCursor()
+	case key.Matches(msg, m.keys.Select) && !m.focusPods:
+		m.eventsTable.ToggleExpanded()
+		m.followEventsCursor()
+	case key.Matches(msg, m.keys.Up):
+		m.podsGrid.Move(0, -1)
+	case key.Matches(msg, m.keys.Down):
+		m.podsGrid.Move(0, 1)
+	case key.Matches(msg, m.keys.Left):
+		m.podsGrid.Move(-1, 0)
+	case key.Matches(msg, m.keys.Right):
+		m.podsGrid.Move(1, 0)
+	case key.Matches(msg, m.keys.Back):
+		switch {
+		case m.eventsTable.Expanded():
+			m.eventsTable.ToggleExpanded()
+		case m.podsGrid.Selected() != nil:
+			m.podsGrid.ClearSelection()
+		default:
+			m.selected = ""
+			m.keys.setDashboardMode(true, true)
+
+			return m.setPodsFocus(false)
+		}
+	}
+
+	m.keys.setSelection(m.dashboard != nil, m.hasSelection())
+
+	return m
+}
+
 // updateComponents dispatches snapshot to all sub-components and sets window title on first data.
 func (m Model) updateComponents(msg SnapshotMsg, firstSnapshot bool) []tea.Cmd {
 	cmds := []tea.Cmd{
@@ -183,13 +474,24 @@ func (m Model) updateComponents(msg SnapshotMsg, firstSnapshot bool) []tea.Cmd {
 		m.progressBar.Update(msg),
 		m.podStats.Update(msg),
 		m.podsGrid.Update(msg),
+		m.problemsTable.Update(msg),
+		m.logsTable.Update(msg),
 		m.eventsTable.Update(msg),
 		m.statusbar.Update(msg),
 	}
 
-	if firstSnapshot {
-		cmds = append(cmds, tea.SetWindowTitle("kubectl-watch-rollout: "+msg.Snapshot.DeploymentName))
+	if firstSnapshot && m.dashboard == nil {
+		cmds = append(cmds, tea.SetWindowTitle("kubectl-watch-rollout: "+msg.Snapshot.WorkloadName))
 	}
 
 	return cmds
 }
+
+// setPodsFocus moves focus between the events panel and the pods grid.
+func (m Model) setPodsFocus(pods bool) Model {
+	m.focusPods = pods
+	m.keys.setPodsFocus(pods)
+	m.podsGrid.SetFocused(pods)
+
+	return m
+}
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ErrPickerCancelled is returned when the user quits the picker without choosing.
+var ErrPickerCancelled = errors.New("no rollout selected")
+
+var pickerTitleStyle = lipgloss.NewStyle().Foreground(ColorGray)
+
+// pickerModel is a minimal inline list for choosing one option.
+type pickerModel struct {
+	title   string
+	options []string
+	cursor  int
+	chosen  string
+	keys    KeyMap
+	help    help.Model
+	done    bool
+}
+
+// Pick shows an inline list of options and returns the one the user selects.
+// Returns ErrPickerCancelled if the user quits instead.
+func Pick(title string, options []string) (string, error) {
+	keys := DefaultKeyMap()
+	keys.setDashboardMode(true, true)
+	keys.Select.SetHelp("enter", "watch")
+
+	model := pickerModel{title: title, options: options, keys: keys, help: help.New()}
+
+	result, err := tea.NewProgram(model).Run()
+	if err != nil {
+		return "", fmt.Errorf("failed to run picker: %w", err)
+	}
+
+	picked, ok := result.(pickerModel)
+	if !ok || picked.chosen == "" {
+		return "", ErrPickerCancelled
+	}
+
+	return picked.chosen, nil
+}
+
+// Init implements tea.Model
+func (m pickerModel) Init() tea.Cmd { return nil }
+
+// Update implements tea.Model
+func (m pickerModel) Update(teaMsg tea.Msg) (tea.Model, tea.Cmd) {
+	msg, ok := teaMsg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.Quit):
+		m.done = true
+
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Up):
+		m.cursor = max(0, m.cursor-1)
+	case key.Matches(msg, m.keys.Down):
+		m.cursor = min(len(m.options)-1, m.cursor+1)
+	case key.Matches(msg, m.keys.Select):
+		m.chosen = m.options[m.cursor]
+		m.done = true
+
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+// View implements tea.Model
+func (m pickerModel) View() string {
+	if m.done {
+		return ""
+	}
+
+	lines := []string{pickerTitleStyle.Render(m.title), ""}
+
+	for i, option := range m.options {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = dashboardCursorStyle.Render(dashboardCursor) + " "
+		}
+
+		lines = append(lines, cursor+option)
+	}
+
+	lines = append(lines, "", pickerTitleStyle.Render(m.help.View(m.keys)), "")
+
+	return strings.Join(lines, "\n")
+}
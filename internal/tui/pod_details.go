@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+var (
+	podReadyStyle    = lipgloss.NewStyle().Foreground(ColorGreen)
+	podNotReadyStyle = lipgloss.NewStyle().Foreground(ColorRed)
+)
+
+// PodDetails is the drill-down panel of the pod selected in the pods grid.
+type PodDetails struct {
+	width int
+	pod   *types.PodInfo
+}
+
+// NewPodDetails creates a new pod details component.
+func NewPodDetails() *PodDetails { return &PodDetails{} }
+
+// SetWidth sets the component width.
+func (m *PodDetails) SetWidth(w int) { m.width = w }
+
+// SetPod sets the pod to describe, nil renders nothing.
+func (m *PodDetails) SetPod(pod *types.PodInfo) { m.pod = pod }
+
+// View renders the component.
+func (m *PodDetails) View() string {
+	if m.pod == nil || m.width <= 0 {
+		return ""
+	}
+
+	pod := m.pod
+	title := sectionTitleStyle.Width(m.width).Render(truncateStr("Pod "+pod.Name, m.width))
+
+	revision := "old"
+	if pod.New {
+		revision = "new"
+	}
+
+	if pod.State == types.PodAbsent {
+		return strings.Join([]string{
+			title,
+			deploymentRow("Revision", revision),
+			deploymentRow("State", formatPodState(pod.State)),
+		}, "\n")
+	}
+
+	rows := []string{
+		title,
+		deploymentRow("Revision", revision),
+		deploymentRow("Node", valueOrDash(pod.Node)),
+		deploymentRow("IP", valueOrDash(pod.IP)),
+		deploymentRow("Phase", valueOrDash(pod.Phase)),
+		deploymentRow("State", formatPodState(pod.State)),
+		deploymentRow("Age", types.FormatDuration(time.Since(pod.Created))),
+		deploymentRow("Restarts", strconv.Itoa(int(pod.Restarts()))),
+	}
+
+	rows = append(rows, "", TableHeaderStyle.Render("CONTAINERS"))
+	for _, c := range pod.Containers {
+		rows = append(rows, truncateLine(formatContainer(c), m.width))
+	}
+
+	if len(pod.ReadinessGates) > 0 {
+		rows = append(rows, "", TableHeaderStyle.Render("READINESS GATES"))
+		for _, g := range pod.ReadinessGates {
+			rows = append(rows, truncateLine(readyMark(g.Ready)+" "+g.Condition, m.width))
+		}
+	}
+
+	rows = append(rows, "", TableHeaderStyle.Render("EVENTS"))
+	if len(pod.Events) == 0 {
+		rows = append(rows, TableLabelStyle.Render("No events"))
+	}
+
+	for _, e := range pod.Events {
+		rows = append(rows, formatPodEvent(e, m.width)...)
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// formatPodState names a pod's lifecycle stage as the grid legend does.
+func formatPodState(state types.PodState) string {
+	switch state {
+	case types.PodAvailable:
+		return "Available"
+	case types.PodReady:
+		return "Ready"
+	case types.PodCurrent:
+		return "Running"
+	case types.PodAbsent:
+		return "Absent"
+	}
+
+	return "Running"
+}
+
+// formatContainer describes a container on one line, e.g. "✗ app Waiting: CrashLoopBackOff (3 restarts)".
+func formatContainer(c types.ContainerInfo) string {
+	line := readyMark(c.Ready) + " " + c.Name
+	if c.Init {
+		line += TableLabelStyle.Render(" (init)")
+	}
+
+	state := valueOrDash(c.State)
+	if c.Reason != "" {
+		state += ": " + c.Reason
+	}
+
+	if c.State == "Terminated" {
+		state += fmt.Sprintf(" (exit %d)", c.ExitCode)
+	}
+
+	line += "  " + state
+
+	if c.Restarts > 0 {
+		line += TableLabelStyle.Render(fmt.Sprintf("  %d restarts", c.Restarts))
+	}
+
+	return line
+}
+
+// formatPodEvent renders an event as a reason line and an indented message line.
+func formatPodEvent(e types.PodEvent, width int) []string {
+	mark := eventsNormalStyle.Render("ℹ")
+	if e.Type == "Warning" {
+		mark = eventsWarningStyle.Render("⚠")
+	}
+
+	seen := fmt.Sprintf("×%d, %s ago", e.Count, types.FormatDuration(time.Since(e.LastSeen)))
+
+	return []string{
+		truncateLine(mark+" "+e.Reason+" "+TableLabelStyle.Render("("+seen+")"), width),
+		"  " + truncateStr(e.Message, max(0, width-2)),
+	}
+}
+
+func readyMark(ready bool) string {
+	if ready {
+		return podReadyStyle.Render("✓")
+	}
+
+	return podNotReadyStyle.Render("✗")
+}
+
+func valueOrDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+
+	return s
+}
+
+// truncateLine cuts a styled line to width.
+func truncateLine(s string, width int) string {
+	return lipgloss.NewStyle().MaxWidth(width).Render(s)
+}
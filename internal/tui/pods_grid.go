@@ -12,6 +12,7 @@ const (
 	symbolAvailable = "■"
 	symbolReady     = "◧"
 	symbolCurrent   = "□"
+	symbolAbsent    = "·"
 )
 
 var (
@@ -20,9 +21,14 @@ var (
 )
 
 // PodsGrid displays individual pod status as symbols in a grid.
+// When the snapshot carries per-pod states, a pod can be selected to show its details.
 type PodsGrid struct {
 	width    int
 	snapshot *types.RolloutSnapshot
+
+	cursor    string // Name of the selected pod, "" when none is selected
+	cursorIdx int    // Position of the selected pod, keeps the selection in place when the pod goes away
+	focused   bool   // Arrow keys select pods
 }
 
 // NewPodsGrid creates a new pods grid component.
@@ -31,15 +37,82 @@ func NewPodsGrid() *PodsGrid { return &PodsGrid{} }
 // SetWidth sets the component width.
 func (m *PodsGrid) SetWidth(w int) { m.width = w }
 
+// SetFocused highlights the title while the arrow keys select pods.
+func (m *PodsGrid) SetFocused(focused bool) { m.focused = focused }
+
 // Update handles messages.
 func (m *PodsGrid) Update(teaMsg tea.Msg) tea.Cmd {
 	if s, ok := teaMsg.(SnapshotMsg); ok {
 		m.snapshot = s.Snapshot
+		m.followCursor()
 	}
 
 	return nil
 }
 
+// followCursor keeps the selection on the same pod, or on its neighbour once the pod is gone.
+func (m *PodsGrid) followCursor() {
+	if m.cursor == "" {
+		return
+	}
+
+	pods := m.snapshot.Pods
+	if len(pods) == 0 {
+		m.ClearSelection()
+
+		return
+	}
+
+	for i, pod := range pods {
+		if pod.Name == m.cursor {
+			m.cursorIdx = i
+
+			return
+		}
+	}
+
+	m.cursorIdx = min(m.cursorIdx, len(pods)-1)
+	m.cursor = pods[m.cursorIdx].Name
+}
+
+// Move moves the selection by dx pods and dy lines, selecting the first pod if none is.
+// Moves past the first or last pod are ignored.
+func (m *PodsGrid) Move(dx, dy int) {
+	if m.snapshot == nil || len(m.snapshot.Pods) == 0 {
+		return
+	}
+
+	pods := m.snapshot.Pods
+
+	if m.cursor == "" {
+		m.cursorIdx = 0
+	} else if next := m.cursorIdx + dx + dy*m.symbolsPerLine(); next >= 0 && next < len(pods) {
+		m.cursorIdx = next
+	}
+
+	m.cursor = pods[m.cursorIdx].Name
+}
+
+// Selected returns the selected pod, nil when none is selected.
+func (m *PodsGrid) Selected() *types.PodInfo {
+	if m.cursor == "" || m.snapshot == nil {
+		return nil
+	}
+
+	return &m.snapshot.Pods[m.cursorIdx]
+}
+
+// ClearSelection deselects the selected pod.
+func (m *PodsGrid) ClearSelection() {
+	m.cursor = ""
+	m.cursorIdx = 0
+}
+
+// symbolsPerLine returns how many pods fit on a line (each symbol is 1 char + 1 space, except last).
+func (m *PodsGrid) symbolsPerLine() int {
+	return max(1, (m.width+1)/2)
+}
+
 // View renders the component.
 func (m *PodsGrid) View() string {
 	if m.snapshot == nil || m.width <= 0 {
@@ -48,10 +121,55 @@ func (m *PodsGrid) View() string {
 
 	// Build title with legend on right
 	left := "Pods"
+
+	switch m.snapshot.Kind {
+	case types.KindStatefulSet:
+		left = "Pods by ordinal"
+	case types.KindDaemonSet:
+		left = "Pods by node"
+	case types.KindDeployment:
+	}
+
 	legend := symbolAvailable + " AVAILABLE  " + symbolReady + " READY  " + symbolCurrent + " RUNNING"
 	gap := max(1, m.width-lipgloss.Width(left)-lipgloss.Width(legend))
 	titleLine := left + strings.Repeat(" ", gap) + legend
-	title := sectionTitleStyle.Width(m.width).Render(titleLine)
+	titleStyle := sectionTitleStyle
+	if m.focused {
+		titleStyle = focusedTitleStyle
+	}
+
+	title := titleStyle.Width(m.width).Render(titleLine)
+
+	symbols := m.buildSymbols()
+	if len(symbols) == 0 {
+		return title
+	}
+
+	// Wrap symbols at width
+	symbolsPerLine := m.symbolsPerLine()
+
+	var lines []string
+
+	for i := 0; i < len(symbols); i += symbolsPerLine {
+		end := min(i+symbolsPerLine, len(symbols))
+		lines = append(lines, strings.Join(symbols[i:end], " "))
+	}
+
+	return title + "\n" + strings.Join(lines, "\n")
+}
+
+// buildSymbols returns one styled symbol per pod.
+// Uses per-pod states when the snapshot carries them (e.g., StatefulSet ordinals),
+// otherwise derives symbols from aggregate counts.
+func (m *PodsGrid) buildSymbols() []string {
+	if len(m.snapshot.Pods) > 0 {
+		symbols := make([]string, len(m.snapshot.Pods))
+		for i, pod := range m.snapshot.Pods {
+			symbols[i] = podSymbol(pod, pod.Name == m.cursor)
+		}
+
+		return symbols
+	}
 
 	// Calculate pod counts (Current >= Ready >= Available)
 	newAvail := int(m.snapshot.NewRS.Available)
@@ -71,24 +189,29 @@ func (m *PodsGrid) View() string {
 	symbols = append(symbols, repeat(oldPodStyle.Render(symbolReady), oldReady)...)
 	symbols = append(symbols, repeat(oldPodStyle.Render(symbolCurrent), oldCurrent)...)
 
-	if len(symbols) == 0 {
-		return title
-	}
+	return symbols
+}
 
-	// Wrap symbols at width (each symbol is 1 char + 1 space, except last)
-	symbolsPerLine := (m.width + 1) / 2
-	if symbolsPerLine <= 0 {
-		symbolsPerLine = 1
+// podSymbol renders a single pod's state, colored by revision and reversed when selected.
+func podSymbol(pod types.PodInfo, selected bool) string {
+	style := oldPodStyle
+	if pod.New {
+		style = newPodStyle
 	}
 
-	var lines []string
+	symbol := symbolCurrent
 
-	for i := 0; i < len(symbols); i += symbolsPerLine {
-		end := min(i+symbolsPerLine, len(symbols))
-		lines = append(lines, strings.Join(symbols[i:end], " "))
+	switch pod.State {
+	case types.PodAvailable:
+		symbol = symbolAvailable
+	case types.PodReady:
+		symbol = symbolReady
+	case types.PodCurrent:
+	case types.PodAbsent:
+		style, symbol = oldPodStyle, symbolAbsent
 	}
 
-	return title + "\n" + strings.Join(lines, "\n")
+	return style.Reverse(selected).Render(symbol)
 }
 
 func repeat(s string, n int) []string {
@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
+)
+
+var problemsReasonStyle = lipgloss.NewStyle().Foreground(ColorRed)
+
+// ProblemsTable is the failing containers component.
+// It renders nothing while all containers of new pods are healthy.
+type ProblemsTable struct {
+	width    int
+	snapshot *types.RolloutSnapshot
+}
+
+// NewProblemsTable creates a new problems component.
+func NewProblemsTable() *ProblemsTable { return &ProblemsTable{} }
+
+// SetWidth sets the component width.
+func (m *ProblemsTable) SetWidth(w int) { m.width = w }
+
+// Update handles messages.
+func (m *ProblemsTable) Update(teaMsg tea.Msg) tea.Cmd {
+	if t, ok := teaMsg.(SnapshotMsg); ok {
+		m.snapshot = t.Snapshot
+	}
+
+	return nil
+}
+
+// View renders the component.
+func (m *ProblemsTable) View() string {
+	if m.snapshot == nil || len(m.snapshot.Problems) == 0 {
+		return ""
+	}
+
+	rows := formatProblemRows(m.snapshot.Problems)
+
+	// Size every column but the message to its content, the message takes the rest
+	headers := []string{"REASON", "CONTAINER", "PODS", "RESTARTS", "LAST EXIT", "MESSAGE"}
+	colWidths := make([]int, len(headers))
+
+	for col, header := range headers {
+		colWidths[col] = len(header) + EventsColPadding
+	}
+
+	for _, r := range rows {
+		for col, value := range []string{r.reason, r.container, r.pods, r.restarts, r.exit} {
+			colWidths[col] = max(colWidths[col], len(value)+EventsColPadding)
+		}
+	}
+
+	msgW := m.width
+	for _, w := range colWidths[:len(colWidths)-1] {
+		msgW -= w
+	}
+
+	msgW = max(EventsMinColW, msgW)
+	colWidths[len(colWidths)-1] = msgW
+
+	tableRows := make([][]string, len(rows))
+	for i, r := range rows {
+		tableRows[i] = []string{
+			problemsReasonStyle.Render(r.reason), r.container, r.pods, r.restarts, r.exit, truncateStr(r.message, msgW),
+		}
+	}
+
+	tbl := table.New().
+		Headers(headers...).
+		Rows(tableRows...).
+		BorderTop(false).BorderBottom(false).BorderLeft(false).BorderRight(false).
+		BorderColumn(false).BorderRow(false).BorderHeader(false).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Width(colWidths[col])
+			if row == table.HeaderRow {
+				return style.Inherit(TableHeaderStyle)
+			}
+
+			return style
+		}).
+		Render()
+
+	return m.buildProblemsTitle() + "\n" + tbl
+}
+
+// buildProblemsTitle creates the section title with the number of affected pods.
+func (m *ProblemsTable) buildProblemsTitle() string {
+	pods := make(map[string]bool)
+	for _, p := range m.snapshot.Problems {
+		for _, pod := range p.Pods {
+			pods[pod] = true
+		}
+	}
+
+	stats := fmt.Sprintf("PODS %d", len(pods))
+	titleContent := "Problems" + lipgloss.PlaceHorizontal(m.width-lipgloss.Width("Problems"), lipgloss.Right, stats)
+
+	return sectionTitleStyle.Width(m.width).Render(titleContent)
+}
+
+// problemRow holds formatted data for a single problem row.
+type problemRow struct {
+	reason, container, pods, restarts, exit, message string
+}
+
+// formatProblemRows converts container problems to formatted row data.
+// A single affected pod is shown by name, several by count.
+func formatProblemRows(problems []types.ContainerProblem) []problemRow {
+	rows := make([]problemRow, len(problems))
+
+	for i, p := range problems {
+		pods := strconv.Itoa(len(p.Pods))
+		if len(p.Pods) == 1 {
+			pods = p.Pods[0]
+		}
+
+		exit := "-"
+		if p.Termination != nil {
+			exit = fmt.Sprintf("%d (%s)", p.Termination.ExitCode, p.Termination.Reason)
+		}
+
+		rows[i] = problemRow{
+			reason:    p.Reason,
+			container: p.Container,
+			pods:      pods,
+			restarts:  strconv.Itoa(int(p.Restarts)),
+			exit:      exit,
+			message:   p.DisplayMessage(),
+		}
+	}
+
+	return rows
+}
@@ -15,6 +15,7 @@ var (
 	deploymentProgressStyle = lipgloss.NewStyle().Foreground(ColorBlue).Bold(true)
 	deploymentCompleteStyle = lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
 	deploymentFailedStyle   = lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
+	deploymentPausedStyle   = lipgloss.NewStyle().Foreground(ColorGray).Bold(true)
 )
 
 // RolloutInfo is the deployment status component.
@@ -42,13 +43,29 @@ func (m *RolloutInfo) View() string {
 
 	s := m.snapshot
 
-	return strings.Join([]string{
-		deploymentRow("Status", renderDeploymentStatus(s.Status)),
-		deploymentRow("ReplicaSet", s.NewRSName),
-		deploymentRow("Strategy", fmt.Sprintf("%s (Unavailable %s, Surge %s)", s.StrategyType, s.MaxUnavailable, s.MaxSurge)),
+	rows := []string{deploymentRow("Status", renderDeploymentStatus(s.Status))}
+
+	if s.StatusMessage != "" {
+		rows = append(rows, deploymentRow("Reason", s.StatusMessage))
+	}
+
+	if s.RollbackRevision > 0 {
+		rows = append(rows, deploymentRow("Rollback", deploymentFailedStyle.Render(
+			fmt.Sprintf("Failed rollout reverted to revision %d", s.RollbackRevision))))
+	}
+
+	rows = append(rows,
+		deploymentRow(s.Kind.RevisionLabel(), s.NewRSName),
+		deploymentRow("Strategy", s.StrategyDescription()),
 		deploymentRow("Started", formatStartedValue(s)),
 		deploymentRow(deploymentETALabel(s), deploymentETAValue(s)),
-	}, "\n")
+	)
+
+	if s.History.Samples > 0 {
+		rows = append(rows, deploymentRow("History", formatHistoryValue(s)))
+	}
+
+	return strings.Join(rows, "\n")
 }
 
 func deploymentRow(label, value string) string {
@@ -59,12 +76,30 @@ func formatStartedValue(s *types.RolloutSnapshot) string {
 	return fmt.Sprintf("%s (%s ago)", s.StartTime.Format("15:04:05"), types.FormatDuration(time.Since(s.StartTime)))
 }
 
+// formatHistoryValue compares the rollout with previous ones, flagging a rollout in progress
+// that already took longer than 9 in 10 of them.
+func formatHistoryValue(s *types.RolloutSnapshot) string {
+	value := fmt.Sprintf("typically takes %s (p50 of last %d)", types.FormatDuration(s.History.P50), s.History.Samples)
+
+	if !s.Status.IsDone() && time.Since(s.StartTime) > s.History.P90 {
+		value += " " + deploymentFailedStyle.Render("slower than usual")
+	}
+
+	return value
+}
+
 func renderDeploymentStatus(status types.RolloutStatus) string {
 	switch status {
 	case types.StatusComplete:
 		return deploymentCompleteStyle.Render("Complete")
 	case types.StatusDeadlineExceeded:
 		return deploymentFailedStyle.Render("Deadline Exceeded")
+	case types.StatusPaused:
+		return deploymentPausedStyle.Render("Paused")
+	case types.StatusReplicaFailure:
+		return deploymentFailedStyle.Render("Replica Failure")
+	case types.StatusSuperseded:
+		return deploymentFailedStyle.Render("Superseded")
 	case types.StatusProgressing:
 		return deploymentProgressStyle.Render("Progressing")
 	}
@@ -73,7 +108,7 @@ func renderDeploymentStatus(status types.RolloutStatus) string {
 }
 
 func deploymentETALabel(s *types.RolloutSnapshot) string {
-	if s.Status == types.StatusComplete || s.Status == types.StatusDeadlineExceeded {
+	if s.Status.IsDone() {
 		return "Duration"
 	}
 
@@ -84,8 +119,12 @@ func deploymentETAValue(s *types.RolloutSnapshot) string {
 	switch {
 	case s.Status == types.StatusComplete && s.ProgressUpdateTime != nil:
 		return types.FormatDuration(s.ProgressUpdateTime.Sub(s.StartTime))
-	case s.Status == types.StatusDeadlineExceeded:
+	case s.Status.IsFailed():
 		return "Failed"
+	case s.Status == types.StatusPaused:
+		return "Paused"
+	case s.Status == types.StatusReplicaFailure:
+		return "Blocked"
 	case s.EstimatedCompletion != nil:
 		if rem := time.Until(*s.EstimatedCompletion); rem > 0 {
 			return fmt.Sprintf("~%s remaining", types.FormatDuration(rem))
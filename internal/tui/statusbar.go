@@ -1,9 +1,12 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/help"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/ivoronin/kubectl-watch-rollout/internal/types"
 )
 
 var (
@@ -13,10 +16,12 @@ var (
 
 // Statusbar is the status bar component.
 type Statusbar struct {
-	width          int
-	deploymentName string
-	help           help.Model
-	keys           help.KeyMap
+	width        int
+	kind         types.WorkloadKind
+	workloadName string
+	summary      string // Replaces the workload name line when set (e.g., dashboard overview)
+	help         help.Model
+	keys         help.KeyMap
 }
 
 // NewStatusbar creates a new status bar component.
@@ -30,10 +35,14 @@ func NewStatusbar(keys help.KeyMap) *Statusbar {
 // SetWidth sets the component width.
 func (m *Statusbar) SetWidth(w int) { m.width = w }
 
+// SetSummary sets a summary shown instead of the watched workload; empty restores it.
+func (m *Statusbar) SetSummary(summary string) { m.summary = summary }
+
 // Update handles messages.
 func (m *Statusbar) Update(teaMsg tea.Msg) tea.Cmd {
 	if t, ok := teaMsg.(SnapshotMsg); ok {
-		m.deploymentName = t.Snapshot.DeploymentName
+		m.kind = t.Snapshot.Kind
+		m.workloadName = t.Snapshot.WorkloadName
 	}
 
 	return nil
@@ -41,7 +50,12 @@ func (m *Statusbar) Update(teaMsg tea.Msg) tea.Cmd {
 
 // View renders the component.
 func (m *Statusbar) View() string {
-	left := statusbarTextStyle.Render("Watching rollout for deployment ") + statusbarNameStyle.Render(m.deploymentName)
+	left := statusbarTextStyle.Render("Watching rollout for "+strings.ToLower(string(m.kind))+" ") +
+		statusbarNameStyle.Render(m.workloadName)
+	if m.summary != "" {
+		left = statusbarTextStyle.Render(m.summary)
+	}
+
 	right := statusbarTextStyle.Render(m.help.View(m.keys))
 	gap := max(0, m.width-lipgloss.Width(left)-lipgloss.Width(right))
 	content := left + lipgloss.NewStyle().Width(gap).Render("") + right
@@ -11,12 +11,13 @@ type View struct {
 	done    chan struct{}
 }
 
-// NewView creates and starts the TUI
-func NewView() *View {
+// NewView creates and starts the TUI.
+// With dashboard set, the TUI lists every workload and drills into one on demand.
+func NewView(dashboard bool) *View {
 	done := make(chan struct{})
 
-	model := NewModel()
-	program := tea.NewProgram(model, tea.WithAltScreen())
+	model := NewModel(dashboard)
+	program := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion())
 
 	view := &View{
 		program: program,
@@ -3,11 +3,33 @@ package types //nolint:revive // types is a standard name for shared domain type
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
+// WorkloadKind identifies the kind of workload whose rollout is monitored.
+type WorkloadKind string
+
+const (
+	// KindDeployment is an apps/v1 Deployment (rolls out via ReplicaSets)
+	KindDeployment WorkloadKind = "Deployment"
+	// KindStatefulSet is an apps/v1 StatefulSet (rolls out via ControllerRevisions, ordinal by ordinal)
+	KindStatefulSet WorkloadKind = "StatefulSet"
+	// KindDaemonSet is an apps/v1 DaemonSet (rolls out via ControllerRevisions, node by node)
+	KindDaemonSet WorkloadKind = "DaemonSet"
+)
+
+// RevisionLabel returns the display label for the object identifying the new revision.
+func (k WorkloadKind) RevisionLabel() string {
+	if k == KindDeployment {
+		return "ReplicaSet"
+	}
+
+	return "Revision"
+}
+
 // RolloutStatus represents the current state of a deployment rollout.
 type RolloutStatus int
 
@@ -18,16 +40,28 @@ const (
 	StatusDeadlineExceeded
 	// StatusComplete indicates rollout completed successfully
 	StatusComplete
+	// StatusPaused indicates the deployment is paused (spec.paused), so the rollout does not advance
+	StatusPaused
+	// StatusReplicaFailure indicates pods cannot be created, e.g. due to an exceeded quota
+	StatusReplicaFailure
+	// StatusSuperseded indicates a newer rollout replaced the awaited one (--revision, --image)
+	StatusSuperseded
 )
 
 // IsDone returns true if rollout is complete or failed
 func (s RolloutStatus) IsDone() bool {
-	return s == StatusComplete || s == StatusDeadlineExceeded
+	return s == StatusComplete || s.IsFailed()
 }
 
 // IsFailed returns true if rollout failed
 func (s RolloutStatus) IsFailed() bool {
-	return s == StatusDeadlineExceeded
+	return s == StatusDeadlineExceeded || s == StatusSuperseded
+}
+
+// IsHalted returns true if rollout is blocked without having failed: paused or unable to create replicas.
+// Whether a halted rollout ends --until-complete depends on the halt policies in the monitor config.
+func (s RolloutStatus) IsHalted() bool {
+	return s == StatusPaused || s == StatusReplicaFailure
 }
 
 // ReplicaSetState groups pod counts for a ReplicaSet at different lifecycle stages.
@@ -37,13 +71,95 @@ type ReplicaSetState struct {
 	Available int32
 }
 
+// PodState is the most advanced lifecycle stage a pod has reached.
+type PodState int
+
+const (
+	// PodAbsent marks an expected pod that does not exist yet (e.g., StatefulSet ordinal being recreated)
+	PodAbsent PodState = iota
+	// PodCurrent indicates the pod exists but is not ready
+	PodCurrent
+	// PodReady indicates the pod passes readiness checks but minReadySeconds has not elapsed
+	PodReady
+	// PodAvailable indicates the pod has been ready for at least minReadySeconds
+	PodAvailable
+)
+
+// PodInfo describes a single pod for the pods grid and its drill-down panel.
+type PodInfo struct {
+	Name  string
+	Node  string // Node the pod is scheduled to, empty if unscheduled
+	New   bool   // Pod runs the updated revision
+	State PodState
+
+	// Details, empty for absent pods
+	IP             string
+	Phase          string // Pod phase, e.g. "Pending" or "Running"
+	Created        time.Time
+	Containers     []ContainerInfo // Init containers first
+	ReadinessGates []ReadinessGate
+	Events         []PodEvent // Events of this pod, most recent first
+}
+
+// Restarts returns the restarts of all containers of the pod.
+func (p PodInfo) Restarts() int32 {
+	var restarts int32
+	for _, c := range p.Containers {
+		restarts += c.Restarts
+	}
+
+	return restarts
+}
+
+// ContainerInfo describes the state of a container of a pod.
+type ContainerInfo struct {
+	Name     string
+	Init     bool
+	Ready    bool
+	State    string // "Running", "Waiting" or "Terminated", empty until the kubelet reports the container
+	Reason   string // Waiting or terminated reason (e.g., "CrashLoopBackOff", "Completed")
+	Message  string
+	ExitCode int32 // Exit code of a terminated container
+	Restarts int32
+}
+
+// ReadinessGate is a pod readiness gate and whether its condition is satisfied.
+type ReadinessGate struct {
+	Condition string
+	Ready     bool
+}
+
+// PodEvent is an event of a single pod.
+type PodEvent struct {
+	Type     string // K8s event type: "Warning" or "Normal"
+	Reason   string
+	Message  string
+	Count    int32
+	LastSeen time.Time
+}
+
+// PodEventSource is the EventCluster source of pod events, which are clustered across pods.
+const PodEventSource = "pod"
+
 // EventCluster represents similar K8s events grouped together for display.
 type EventCluster struct {
+	Source        string    // Involved object: "pod" for pod events, otherwise "kind/name" (e.g., "replicaset/web-5d8f")
 	Type          string    // K8s event type: "Warning" or "Normal"
 	Reason        string    // K8s event reason (e.g., "FailedScheduling", "Unhealthy")
 	Message       string    // Truncated representative message
 	ExemplarCount int       // Total events matching this template
 	LastSeen      time.Time // Most recent occurrence in cluster
+	FirstSeen     time.Time // Earliest occurrence in cluster
+
+	Exemplars []EventExemplar // Distinct messages behind the template, most recent first
+	Pods      []string        // Pods the events are about, sorted (pod events only)
+}
+
+// EventExemplar is a distinct message of an event cluster.
+type EventExemplar struct {
+	Message  string
+	Count    int       // Events with this message
+	LastSeen time.Time // Most recent event with this message
 }
 
 // Symbol returns a visual symbol for display based on event Type.
@@ -55,28 +171,87 @@ func (e EventCluster) Symbol() string {
 	return "ℹ"
 }
 
+// IsPodEvent reports whether the cluster groups events of pods rather than of a workload object.
+func (e EventCluster) IsPodEvent() bool {
+	return e.Source == PodEventSource
+}
+
 // EventSummary is the result of event processing, ready for rendering.
 type EventSummary struct {
 	Clusters     []EventCluster // Event clusters ready for display
 	IgnoredCount int            // Events filtered by ignore regex
 }
 
+// ContainerTermination describes how a container last terminated.
+type ContainerTermination struct {
+	Reason   string // e.g., "OOMKilled", "Error"
+	ExitCode int32
+	Message  string // Termination message written by the container, often empty
+}
+
+// ContainerProblem groups containers of new pods that are stuck or failing for the same reason.
+type ContainerProblem struct {
+	Container   string                // Container name (init containers included)
+	Reason      string                // Waiting or terminated reason (e.g., "CrashLoopBackOff", "ImagePullBackOff")
+	Message     string                // Representative kubelet message
+	Pods        []string              // Affected pods, sorted by name
+	Restarts    int32                 // Highest restart count among affected containers
+	Termination *ContainerTermination // Most recent termination, nil if the container never terminated
+}
+
+// LogCluster represents similar container log lines grouped together for display.
+type LogCluster struct {
+	Container string // Container the lines came from
+	Previous  bool   // Lines came from the previous, terminated container instance
+	Template  string // Representative line, variable tokens replaced with <*>
+	Count     int    // Lines matching this template
+}
+
+// LogSummary is the result of log processing for failing containers, ready for rendering.
+type LogSummary struct {
+	Clusters []LogCluster // Most frequent templates first
+	Pods     []string     // Pods whose logs were sampled, sorted by name
+	Lines    int          // Total log lines clustered
+}
+
+// DisplayMessage returns the kubelet message, falling back to the container's termination message.
+// Whitespace is collapsed so multi-line termination messages fit on one line.
+func (p ContainerProblem) DisplayMessage() string {
+	msg := p.Message
+	if msg == "" && p.Termination != nil {
+		msg = p.Termination.Message
+	}
+
+	return strings.Join(strings.Fields(msg), " ")
+}
+
+// RolloutHistory summarizes how long previous successful rollouts of a workload took.
+type RolloutHistory struct {
+	Samples int           // Completed rollouts summarized, 0 without history
+	P50     time.Duration // Median duration
+	P90     time.Duration
+}
+
 // RolloutSnapshot represents a snapshot of the deployment rollout state.
 // This is a pure domain DTO with no infrastructure dependencies.
 type RolloutSnapshot struct {
-	// Deployment identification
-	DeploymentName string
-	NewRSName      string
+	// Workload identification
+	Kind         WorkloadKind
+	WorkloadName string
+	NewRSName    string // New ReplicaSet (Deployment) or update ControllerRevision (StatefulSet, DaemonSet)
+	Revision     int64  // Revision number of NewRSName, 0 if unknown
 
 	// Rollout strategy
 	StrategyType   string
-	MaxSurge       string
+	MaxSurge       string // Empty when the strategy has no surge
 	MaxUnavailable string
+	Partition      *int32 // StatefulSet partition, nil when not applicable
 
 	// Pod state tracking (grouped by ReplicaSet)
 	Desired int32
 	NewRS   ReplicaSetState
 	OldRS   ReplicaSetState
+	Pods    []PodInfo // Per-pod states in display order, empty when only counts are known
 
 	// Progress (0-1 ratios)
 	NewProgress float64
@@ -89,8 +264,47 @@ type RolloutSnapshot struct {
 	EstimatedCompletion *time.Time
 
 	// Status and events
-	Status RolloutStatus
-	Events EventSummary
+	Status           RolloutStatus
+	StatusMessage    string // Explains the status: generation not yet observed, ReplicaFailure reason, paused
+	Waiting          bool   // No rollout data yet (several workloads only), StatusMessage says why
+	RollbackRevision int64  // Revision a failed rollout was rolled back to, 0 if not rolled back
+	Events           EventSummary
+	Problems         []ContainerProblem // Failing containers of new pods, most widespread first
+	Logs             LogSummary         // Clustered logs of failing containers
+	History          RolloutHistory     // Durations of previous rollouts of the workload
+}
+
+// UpdateTarget returns how many replicas the rollout is expected to update.
+// Partitioned StatefulSets only update ordinals at or above the partition.
+func (s *RolloutSnapshot) UpdateTarget() int32 {
+	if s.Partition != nil {
+		return max(0, s.Desired-*s.Partition)
+	}
+
+	return s.Desired
+}
+
+// StrategyDescription formats the rollout strategy with its parameters.
+func (s *RolloutSnapshot) StrategyDescription() string {
+	var params []string
+
+	if s.Partition != nil {
+		params = append(params, fmt.Sprintf("Partition %d", *s.Partition))
+	}
+
+	if s.MaxUnavailable != "" {
+		params = append(params, "Unavailable "+s.MaxUnavailable)
+	}
+
+	if s.MaxSurge != "" {
+		params = append(params, "Surge "+s.MaxSurge)
+	}
+
+	if len(params) == 0 {
+		return s.StrategyType
+	}
+
+	return fmt.Sprintf("%s (%s)", s.StrategyType, strings.Join(params, ", "))
 }
 
 // View defines the interface for presenting rollout information.